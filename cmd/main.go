@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
-	"github.com/blazee5/quizmaster-backend/internal/email/handler"
+	emailHandler "github.com/blazee5/quizmaster-backend/internal/email/handler"
+	rabbitProducer "github.com/blazee5/quizmaster-backend/internal/rabbitmq"
+	resultHandler "github.com/blazee5/quizmaster-backend/internal/result/handler"
 	"github.com/blazee5/quizmaster-backend/internal/routes"
 	"github.com/blazee5/quizmaster-backend/lib/db/aws"
 	"github.com/blazee5/quizmaster-backend/lib/db/postgres"
@@ -10,6 +12,7 @@ import (
 	"github.com/blazee5/quizmaster-backend/lib/elastic"
 	"github.com/blazee5/quizmaster-backend/lib/logger"
 	"github.com/blazee5/quizmaster-backend/lib/rabbitmq"
+	"github.com/blazee5/quizmaster-backend/lib/random"
 	"github.com/blazee5/quizmaster-backend/lib/tracer"
 	libValidator "github.com/blazee5/quizmaster-backend/lib/validator"
 	"github.com/go-playground/validator/v10"
@@ -42,6 +45,10 @@ func main() {
 		panic("Error loading .env file")
 	}
 
+	if err := random.InitShuffleSecret(); err != nil {
+		panic(err)
+	}
+
 	log := logger.NewLogger()
 	db := postgres.New()
 	rdb := redis.NewRedisClient()
@@ -50,6 +57,8 @@ func main() {
 	trace := tracer.InitTracer("Quizmaster")
 	awsClient := aws.NewAWSClient()
 	rabbitConn := rabbitmq.NewRabbitMQConn()
+	producer := rabbitProducer.NewProducer(log, rabbitConn)
+	producer.InitProducer()
 
 	e := echo.New()
 	e.Use(middleware.Recover())
@@ -60,7 +69,7 @@ func main() {
 	}))
 
 	e.Validator = libValidator.NewValidator(validator.New())
-	server := routes.NewServer(e, log, db, rdb, esClient, ws, trace, awsClient, rabbitConn)
+	server := routes.NewServer(e, log, db, rdb, esClient, ws, trace, awsClient, producer)
 
 	go func() {
 		log.Fatal(server.Run())
@@ -71,7 +80,11 @@ func main() {
 	}()
 
 	go func() {
-		handler.InitEmailConsumer(context.Background(), log, rabbitConn)
+		emailHandler.InitEmailConsumer(context.Background(), log, rabbitConn)
+	}()
+
+	go func() {
+		resultHandler.InitResultWorker(context.Background(), log, db, ws, producer, trace)
 	}()
 
 	quit := make(chan os.Signal, 1)
@@ -24,8 +24,12 @@ func (repo *Repository) Create(ctx context.Context, userID int, input domain.Qui
 
 	var id int
 
-	err := repo.db.QueryRowxContext(ctx, "INSERT INTO quizzes (title, description, user_id) VALUES ($1, $2, $3) RETURNING id",
-		input.Title, input.Description, userID).Scan(&id)
+	err := repo.db.QueryRowxContext(ctx, `INSERT INTO quizzes (title, description, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy,
+		shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), 'best'), $9, $10, $11, $12,
+		COALESCE(NULLIF($13, ''), 'public'), CASE WHEN $13 = 'access_code' THEN $14 ELSE '' END, $15, $16) RETURNING id`,
+		input.Title, input.Description, userID, input.TimeLimit, input.ShowAnswers, input.MaxAttempts, input.AttemptCooldown, input.ScorePolicy,
+		input.ShuffleQuestions, input.ShuffleAnswers, input.PoolSize, input.PoolByTag, input.Visibility, input.AccessCode, input.OpensAt, input.ClosesAt).Scan(&id)
 
 	if err != nil {
 		span.RecordError(err)
@@ -43,7 +47,7 @@ func (repo *Repository) GetQuizzes(ctx context.Context) ([]models.Quiz, error) {
 
 	quizzes := make([]models.Quiz, 0)
 
-	err := repo.db.SelectContext(ctx, &quizzes, "SELECT id, title, description, image, user_id, created_at FROM quizzes")
+	err := repo.db.SelectContext(ctx, &quizzes, "SELECT id, title, description, image, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy, shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at, status, version_id, created_at FROM quizzes")
 
 	if err != nil {
 		span.RecordError(err)
@@ -59,8 +63,15 @@ func (repo *Repository) Update(ctx context.Context, id int, input domain.Quiz) e
 	ctx, span := repo.tracer.Start(ctx, "admin.quizRepo.Update")
 	defer span.End()
 
-	err := repo.db.QueryRowxContext(ctx, "UPDATE quizzes SET title = $1, description = $2 WHERE id = $3",
-		input.Title, input.Description, id).Err()
+	err := repo.db.QueryRowxContext(ctx, `UPDATE quizzes SET title = $1, description = $2, time_limit = $3, show_answers = $4,
+		max_attempts = $5, attempt_cooldown = $6, score_policy = COALESCE(NULLIF($7, ''), score_policy),
+		shuffle_questions = $8, shuffle_answers = $9, pool_size = $10, pool_by_tag = $11,
+		visibility = COALESCE(NULLIF($13, ''), visibility),
+		access_code = CASE WHEN COALESCE(NULLIF($13, ''), visibility) = 'access_code' THEN COALESCE(NULLIF($14, ''), access_code) ELSE '' END,
+		opens_at = $15, closes_at = $16
+		WHERE id = $12`,
+		input.Title, input.Description, input.TimeLimit, input.ShowAnswers, input.MaxAttempts, input.AttemptCooldown, input.ScorePolicy,
+		input.ShuffleQuestions, input.ShuffleAnswers, input.PoolSize, input.PoolByTag, id, input.Visibility, input.AccessCode, input.OpensAt, input.ClosesAt).Err()
 
 	if err != nil {
 		span.RecordError(err)
@@ -0,0 +1,8 @@
+package answer
+
+import "context"
+
+type AWSRepository interface {
+	SaveFile(ctx context.Context, fileName, contentType string, chunk []byte) error
+	DeleteFile(ctx context.Context, fileName string) error
+}
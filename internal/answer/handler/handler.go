@@ -70,6 +70,7 @@ func (h *Handler) GetAnswers(c echo.Context) error {
 	ctx, span := h.tracer.Start(c.Request().Context(), "answer.GetAnswers")
 	defer span.End()
 
+	userID := c.Get("userID").(int)
 	quizID, err := strconv.Atoi(c.Param("id"))
 
 	if err != nil {
@@ -86,7 +87,13 @@ func (h *Handler) GetAnswers(c echo.Context) error {
 		})
 	}
 
-	answers, err := h.service.GetByQuestionID(ctx, quizID, questionID)
+	answers, err := h.service.GetByQuestionID(ctx, userID, quizID, questionID, c.Request().Header.Get("X-Access-Code"))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "question not found",
+		})
+	}
 
 	if errors.Is(err, http_errors.ErrPermissionDenied) {
 		return c.JSON(http.StatusForbidden, echo.Map{
@@ -94,6 +101,12 @@ func (h *Handler) GetAnswers(c echo.Context) error {
 		})
 	}
 
+	if errors.Is(err, http_errors.ErrAccessCodeRequired) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "access code required",
+		})
+	}
+
 	if err != nil {
 		h.log.Infof("error while get answers by question id: %s", err)
 		return c.JSON(http.StatusInternalServerError, echo.Map{
@@ -163,6 +176,12 @@ func (h *Handler) UpdateAnswer(c echo.Context) error {
 		})
 	}
 
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
 	if err != nil {
 		h.log.Infof("error while update answer: %s", err)
 		return c.JSON(http.StatusInternalServerError, echo.Map{
@@ -220,6 +239,126 @@ func (h *Handler) DeleteAnswer(c echo.Context) error {
 	return c.String(http.StatusOK, "OK")
 }
 
+func (h *Handler) UploadImage(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "answer.UploadImage")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	questionID, err := strconv.Atoi(c.Param("questionID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid question id",
+		})
+	}
+
+	answerID, err := strconv.Atoi(c.Param("answerID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid answer id",
+		})
+	}
+
+	file, err := c.FormFile("image")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "file is required",
+		})
+	}
+
+	err = h.service.UploadImage(ctx, answerID, userID, quizID, questionID, file)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "answer not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrInvalidImage) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid image",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while upload answer image: %s", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.String(http.StatusOK, "OK")
+}
+
+func (h *Handler) DeleteImage(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "answer.DeleteImage")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	questionID, err := strconv.Atoi(c.Param("questionID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid question id",
+		})
+	}
+
+	answerID, err := strconv.Atoi(c.Param("answerID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid answer id",
+		})
+	}
+
+	err = h.service.DeleteImage(ctx, answerID, userID, quizID, questionID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "answer not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while delete answer image: %s", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.String(http.StatusOK, "OK")
+}
+
 func (h *Handler) ChangeOrder(c echo.Context) error {
 	ctx, span := h.tracer.Start(c.Request().Context(), "answer.ChangeOrder")
 	defer span.End()
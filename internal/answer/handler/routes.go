@@ -5,22 +5,28 @@ import (
 	answerService "github.com/blazee5/quizmaster-backend/internal/answer/service"
 	questionRepo "github.com/blazee5/quizmaster-backend/internal/question/repository"
 	quizRepo "github.com/blazee5/quizmaster-backend/internal/quiz/repository"
+	resultRepo "github.com/blazee5/quizmaster-backend/internal/result/repository"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
+	"github.com/minio/minio-go/v7"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-func InitAnswerRoutes(answerGroup *echo.Group, log *zap.SugaredLogger, db *sqlx.DB, tracer trace.Tracer) {
+func InitAnswerRoutes(answerGroup *echo.Group, log *zap.SugaredLogger, db *sqlx.DB, awsClient *minio.Client, tracer trace.Tracer) {
 	repos := answerRepo.NewRepository(db, tracer)
+	awsRepos := answerRepo.NewAWSRepository(awsClient)
 	quizRepos := quizRepo.NewRepository(db, tracer)
 	questionRepos := questionRepo.NewRepository(db, tracer)
-	services := answerService.NewService(log, repos, quizRepos, questionRepos, tracer)
+	resultRepos := resultRepo.NewRepository(db, tracer)
+	services := answerService.NewService(log, repos, quizRepos, questionRepos, resultRepos, awsRepos, tracer)
 	handlers := NewHandler(log, services, tracer)
 
 	answerGroup.GET("", handlers.GetAnswers)
 	answerGroup.POST("", handlers.CreateAnswer)
+	answerGroup.POST("/:answerID/image", handlers.UploadImage)
 	answerGroup.PUT("/:answerID", handlers.UpdateAnswer)
 	answerGroup.PUT("/order", handlers.ChangeOrder)
 	answerGroup.DELETE("/:answerID", handlers.DeleteAnswer)
+	answerGroup.DELETE("/:answerID/image", handlers.DeleteImage)
 }
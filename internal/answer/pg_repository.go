@@ -13,5 +13,7 @@ type Repository interface {
 	Create(ctx context.Context, questionID int) (int, error)
 	Update(ctx context.Context, answerID int, input domain.Answer) error
 	Delete(ctx context.Context, answerID int) error
+	UploadImage(ctx context.Context, answerID int, filename string) error
+	DeleteImage(ctx context.Context, answerID int) error
 	ChangeOrder(ctx context.Context, questionID int, input domain.AnswerOrder) error
 }
@@ -69,7 +69,7 @@ func (repo *Repository) GetAnswersInfoByQuestionID(ctx context.Context, question
 
 	answers := make([]models.AnswerInfo, 0)
 
-	err := repo.db.SelectContext(ctx, &answers, `SELECT a.id, a.text, a.question_id, a.order_id FROM answers a
+	err := repo.db.SelectContext(ctx, &answers, `SELECT a.id, a.text, a.image, a.question_id, a.order_id, a.match_text FROM answers a
 		WHERE a.question_id = $1 ORDER BY a.order_id`, questionID)
 
 	if err != nil {
@@ -85,9 +85,10 @@ func (repo *Repository) Update(ctx context.Context, answerID int, input domain.A
 
 	err := repo.db.QueryRowxContext(ctx, `UPDATE answers SET
 		text = $1,
-		is_correct = $2
-		WHERE id = $3`,
-		input.Text, input.IsCorrect, answerID).Err()
+		is_correct = $2,
+		match_text = $3
+		WHERE id = $4`,
+		input.Text, input.IsCorrect, input.MatchText, answerID).Err()
 
 	if err != nil {
 		return err
@@ -109,6 +110,32 @@ func (repo *Repository) Delete(ctx context.Context, answerID int) error {
 	return nil
 }
 
+func (repo *Repository) UploadImage(ctx context.Context, answerID int, filename string) error {
+	ctx, span := repo.tracer.Start(ctx, "answerRepo.UploadImage")
+	defer span.End()
+
+	err := repo.db.QueryRowxContext(ctx, "UPDATE answers SET image = $1 WHERE id = $2", filename, answerID).Err()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *Repository) DeleteImage(ctx context.Context, answerID int) error {
+	ctx, span := repo.tracer.Start(ctx, "answerRepo.DeleteImage")
+	defer span.End()
+
+	err := repo.db.QueryRowxContext(ctx, "UPDATE answers SET image = '' WHERE id = $1", answerID).Err()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (repo *Repository) ChangeOrder(ctx context.Context, questionID int, input domain.AnswerOrder) error {
 	ctx, span := repo.tracer.Start(ctx, "answerRepo.ChangeOrder")
 	defer span.End()
@@ -4,12 +4,15 @@ import (
 	"context"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	"github.com/blazee5/quizmaster-backend/internal/models"
+	"mime/multipart"
 )
 
 type Service interface {
 	Create(ctx context.Context, userID, quizID, questionID int) (int, error)
-	GetByQuestionID(ctx context.Context, quizID, questionID int) ([]models.AnswerInfo, error)
+	GetByQuestionID(ctx context.Context, userID, quizID, questionID int, accessCode string) ([]models.AnswerInfo, error)
 	Update(ctx context.Context, answerID, userID, quizID, questionID int, input domain.Answer) error
 	Delete(ctx context.Context, answerID, userID, quizID, questionID int) error
+	UploadImage(ctx context.Context, answerID, userID, quizID, questionID int, fileHeader *multipart.FileHeader) error
+	DeleteImage(ctx context.Context, answerID, userID, quizID, questionID int) error
 	ChangeOrder(ctx context.Context, userID, quizID, questionID int, input domain.AnswerOrder) error
 }
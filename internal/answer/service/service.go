@@ -2,14 +2,25 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	answerRepo "github.com/blazee5/quizmaster-backend/internal/answer"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	"github.com/blazee5/quizmaster-backend/internal/models"
 	questionRepo "github.com/blazee5/quizmaster-backend/internal/question"
+	"github.com/blazee5/quizmaster-backend/internal/question/content"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
 	quizRepo "github.com/blazee5/quizmaster-backend/internal/quiz"
+	"github.com/blazee5/quizmaster-backend/internal/quiz/media"
+	"github.com/blazee5/quizmaster-backend/internal/quiz/visibility"
+	resultRepo "github.com/blazee5/quizmaster-backend/internal/result"
+	"github.com/blazee5/quizmaster-backend/lib/files"
 	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/blazee5/quizmaster-backend/lib/random"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"mime/multipart"
 )
 
 type Service struct {
@@ -17,11 +28,13 @@ type Service struct {
 	repo         answerRepo.Repository
 	quizRepo     quizRepo.Repository
 	questionRepo questionRepo.Repository
+	resultRepo   resultRepo.Repository
+	awsRepo      answerRepo.AWSRepository
 	tracer       trace.Tracer
 }
 
-func NewService(log *zap.SugaredLogger, repo answerRepo.Repository, quizRepo quizRepo.Repository, questionRepo questionRepo.Repository, tracer trace.Tracer) *Service {
-	return &Service{log: log, repo: repo, quizRepo: quizRepo, questionRepo: questionRepo, tracer: tracer}
+func NewService(log *zap.SugaredLogger, repo answerRepo.Repository, quizRepo quizRepo.Repository, questionRepo questionRepo.Repository, resultRepo resultRepo.Repository, awsRepo answerRepo.AWSRepository, tracer trace.Tracer) *Service {
+	return &Service{log: log, repo: repo, quizRepo: quizRepo, questionRepo: questionRepo, resultRepo: resultRepo, awsRepo: awsRepo, tracer: tracer}
 }
 
 func (s *Service) Create(ctx context.Context, userID, quizID, questionID int) (int, error) {
@@ -47,39 +60,137 @@ func (s *Service) Create(ctx context.Context, userID, quizID, questionID int) (i
 	return s.repo.Create(ctx, questionID)
 }
 
-func (s *Service) GetByQuestionID(ctx context.Context, quizID, questionID int) ([]models.AnswerInfo, error) {
+func (s *Service) GetByQuestionID(ctx context.Context, userID, quizID, questionID int, accessCode string) ([]models.AnswerInfo, error) {
 	ctx, span := s.tracer.Start(ctx, "answerService.GetByQuestionID")
 	defer span.End()
 
-	_, err := s.quizRepo.GetByID(ctx, quizID)
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
 
 	if err != nil {
 		return nil, err
 	}
 
-	question, err := s.questionRepo.GetQuestionByID(ctx, questionID)
+	attempt, err := s.resultRepo.GetByUserID(ctx, userID, quizID)
+	hasAttempt := err == nil
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if !hasAttempt {
+		if err := visibility.Check(ctx, s.quizRepo, quiz, userID, accessCode); err != nil {
+			return nil, err
+		}
+	}
+
+	versionID := quiz.VersionID
+
+	if hasAttempt && attempt.VersionID != nil {
+		versionID = attempt.VersionID
+	}
+
+	questionType, answers, err := s.getPublishedAnswers(ctx, quiz, userID, questionID, versionID)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if question.Type == "input" {
-		return nil, http_errors.ErrPermissionDenied
+	if quiz.ShuffleAnswers && hasAttempt {
+		random.Shuffle(answers, attempt.ID, questionID)
 	}
 
-	return s.repo.GetAnswersInfoByQuestionID(ctx, questionID)
+	types.HideKey(questionType, answers, attempt.ID, questionID)
+	content.AnswersInfo(answers)
+
+	return answers, nil
+}
+
+// getPublishedAnswers returns the answers of a question in the given quiz version.
+// A quiz that was never published only shows its draft answers to the owner.
+// The question type is returned along with the answers.
+func (s *Service) getPublishedAnswers(ctx context.Context, quiz models.Quiz, userID, questionID int, versionID *int) (string, []models.AnswerInfo, error) {
+	if versionID == nil {
+		if quiz.UserID != userID {
+			return "", nil, sql.ErrNoRows
+		}
+
+		question, err := s.questionRepo.GetQuestionByID(ctx, questionID)
+
+		if err != nil {
+			return "", nil, err
+		}
+
+		if question.QuizID != quiz.ID {
+			return "", nil, sql.ErrNoRows
+		}
+
+		if !types.HasOptions(question.Type) {
+			return "", nil, http_errors.ErrPermissionDenied
+		}
+
+		answers, err := s.repo.GetAnswersInfoByQuestionID(ctx, questionID)
+
+		if err != nil {
+			return "", nil, err
+		}
+
+		return question.Type, answers, nil
+	}
+
+	version, err := s.quizRepo.GetVersion(ctx, *versionID)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, question := range version.Questions {
+		if question.ID != questionID {
+			continue
+		}
+
+		if !types.HasOptions(question.Type) {
+			return "", nil, http_errors.ErrPermissionDenied
+		}
+
+		answers := make([]models.AnswerInfo, 0, len(question.Answers))
+
+		for _, answer := range question.Answers {
+			answers = append(answers, models.AnswerInfo{
+				ID:         answer.ID,
+				Text:       answer.Text,
+				Image:      answer.Image,
+				QuestionID: answer.QuestionID,
+				OrderID:    answer.OrderID,
+				MatchText:  answer.MatchText,
+			})
+		}
+
+		return question.Type, answers, nil
+	}
+
+	return "", nil, sql.ErrNoRows
 }
 
 func (s *Service) Update(ctx context.Context, answerID, userID, quizID, questionID int, input domain.Answer) error {
 	ctx, span := s.tracer.Start(ctx, "answerService.Update")
 	defer span.End()
 
-	err := s.checkPermissions(ctx, userID, quizID, questionID, answerID)
+	_, err := s.checkPermissions(ctx, userID, quizID, questionID, answerID)
+
+	if err != nil {
+		return err
+	}
+
+	question, err := s.questionRepo.GetQuestionByID(ctx, questionID)
 
 	if err != nil {
 		return err
 	}
 
+	if err := types.ValidateAnswers(question.Type, question.MatchMode, []domain.Answer{input}); err != nil {
+		return fmt.Errorf("%w: %v", http_errors.ErrWrongArgument, err)
+	}
+
 	return s.repo.Update(ctx, answerID, input)
 }
 
@@ -87,20 +198,82 @@ func (s *Service) Delete(ctx context.Context, answerID, userID, quizID, question
 	ctx, span := s.tracer.Start(ctx, "answerService.Delete")
 	defer span.End()
 
-	err := s.checkPermissions(ctx, userID, quizID, questionID, answerID)
+	answer, err := s.checkPermissions(ctx, userID, quizID, questionID, answerID)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(ctx, answerID)
+
+	if err != nil {
+		return err
+	}
+
+	media.DeleteUnused(ctx, s.log, s.quizRepo, s.awsRepo, quizID, []string{answer.Image})
+
+	return nil
+}
+
+func (s *Service) UploadImage(ctx context.Context, answerID, userID, quizID, questionID int, fileHeader *multipart.FileHeader) error {
+	ctx, span := s.tracer.Start(ctx, "answerService.UploadImage")
+	defer span.End()
+
+	answer, err := s.checkPermissions(ctx, userID, quizID, questionID, answerID)
+
+	if err != nil {
+		return err
+	}
+
+	contentType, bytes, fileName, err := files.PrepareImage(fileHeader)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.awsRepo.SaveFile(ctx, fileName, contentType, bytes)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.UploadImage(ctx, answerID, fileName)
 
 	if err != nil {
 		return err
 	}
 
-	return s.repo.Delete(ctx, answerID)
+	media.DeleteUnused(ctx, s.log, s.quizRepo, s.awsRepo, quizID, []string{answer.Image})
+
+	return nil
+}
+
+func (s *Service) DeleteImage(ctx context.Context, answerID, userID, quizID, questionID int) error {
+	ctx, span := s.tracer.Start(ctx, "answerService.DeleteImage")
+	defer span.End()
+
+	answer, err := s.checkPermissions(ctx, userID, quizID, questionID, answerID)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteImage(ctx, answerID)
+
+	if err != nil {
+		return err
+	}
+
+	media.DeleteUnused(ctx, s.log, s.quizRepo, s.awsRepo, quizID, []string{answer.Image})
+
+	return nil
 }
 
 func (s *Service) ChangeOrder(ctx context.Context, userID, quizID, questionID int, input domain.AnswerOrder) error {
 	ctx, span := s.tracer.Start(ctx, "answerService.ChangeOrder")
 	defer span.End()
 
-	err := s.checkPermissions(ctx, userID, quizID, questionID, input.AnswerID)
+	_, err := s.checkPermissions(ctx, userID, quizID, questionID, input.AnswerID)
 
 	if err != nil {
 		return err
@@ -115,28 +288,29 @@ func (s *Service) ChangeOrder(ctx context.Context, userID, quizID, questionID in
 	return nil
 }
 
-func (s *Service) checkPermissions(ctx context.Context, userID, quizID, questionID, answerID int) error {
+// checkPermissions returns the answer if it belongs to the question of a quiz owned by the user.
+func (s *Service) checkPermissions(ctx context.Context, userID, quizID, questionID, answerID int) (models.Answer, error) {
 	quiz, err := s.quizRepo.GetByID(ctx, quizID)
 
 	if err != nil {
-		return err
+		return models.Answer{}, err
 	}
 
 	question, err := s.questionRepo.GetQuestionByID(ctx, questionID)
 
 	if err != nil {
-		return err
+		return models.Answer{}, err
 	}
 
 	answer, err := s.repo.GetByID(ctx, answerID)
 
 	if err != nil {
-		return err
+		return models.Answer{}, err
 	}
 
 	if quiz.UserID != userID || question.QuizID != quizID || answer.QuestionID != questionID {
-		return http_errors.ErrPermissionDenied
+		return models.Answer{}, http_errors.ErrPermissionDenied
 	}
 
-	return nil
+	return answer, nil
 }
@@ -8,16 +8,13 @@ import (
 	userRepo "github.com/blazee5/quizmaster-backend/internal/user/repository"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
-	amqp "github.com/rabbitmq/amqp091-go"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-func InitAuthRoutes(authGroup *echo.Group, log *zap.SugaredLogger, db *sqlx.DB, rabbitConn *amqp.Connection, tracer trace.Tracer) {
+func InitAuthRoutes(authGroup *echo.Group, log *zap.SugaredLogger, db *sqlx.DB, producer rabbitmq.QueueProducer, tracer trace.Tracer) {
 	repos := authRepo.NewRepository(db, tracer)
 	userRepos := userRepo.NewRepository(db, tracer)
-	producer := rabbitmq.NewProducer(log, rabbitConn)
-	producer.InitProducer()
 	services := authService.NewService(log, repos, userRepos, producer, tracer)
 	handlers := NewHandler(log, services, tracer)
 
@@ -1,17 +1,25 @@
 package domain
 
 type Answer struct {
-	Text       string `json:"text"`
+	Text       string `json:"text" validate:"max=2000"`
 	IsCorrect  bool   `json:"is_correct"`
 	OrderID    int    `json:"order_id"`
-	QuestionID int
+	MatchText  string `json:"match_text" validate:"max=255"`
+	QuestionID int    `json:"-"`
 }
 
 type UserAnswer struct {
-	AttemptID  int    `json:"attempt_id" validate:"required"`
-	QuestionID int    `json:"question_id" validate:"required"`
-	AnswerID   int    `json:"answer_id"`
-	AnswerText string `json:"answer_text"`
+	AttemptID  int          `json:"attempt_id" validate:"required"`
+	QuestionID int          `json:"question_id" validate:"required"`
+	AnswerID   int          `json:"answer_id"`
+	AnswerIDs  []int        `json:"answer_ids"`
+	AnswerText string       `json:"answer_text" validate:"max=10000"`
+	Pairs      []AnswerPair `json:"pairs" validate:"dive"`
+}
+
+type AnswerPair struct {
+	AnswerID  int    `json:"answer_id" validate:"required"`
+	MatchText string `json:"match_text"`
 }
 
 type AnswerOrder struct {
@@ -1,10 +1,17 @@
 package domain
 
 type Question struct {
-	Title   string `json:"title"`
-	Type    string `json:"type" validate:"required,oneof=choice input"`
-	OrderID int    `json:"order_id"`
-	QuizID  int
+	Title         string `json:"title" validate:"max=10000"`
+	Type          string `json:"type" validate:"required,oneof=choice input multiple true_false ordering matching numeric essay"`
+	OrderID       int    `json:"order_id"`
+	Scoring       string `json:"scoring" validate:"omitempty,oneof=all_or_nothing proportional penalty"`
+	Points        *int   `json:"points" validate:"omitempty,gte=0"`
+	Tag           string `json:"tag" validate:"max=255"`
+	MatchMode     string `json:"match_mode" validate:"omitempty,oneof=exact normalized regex numeric"`
+	IgnoreAccents bool   `json:"ignore_accents"`
+	TypoTolerance int    `json:"typo_tolerance" validate:"gte=0,lte=10"`
+	Explanation   string `json:"explanation" validate:"max=10000"`
+	QuizID        int    `json:"-"`
 }
 
 type QuestionOrder struct {
@@ -1,6 +1,67 @@
 package domain
 
+import "time"
+
 type Quiz struct {
-	Title       string `form:"title" validate:"required"`
-	Description string `form:"description"`
+	Title            string     `json:"title" form:"title" validate:"required"`
+	Description      string     `json:"description" form:"description"`
+	TimeLimit        int        `json:"time_limit" form:"time_limit" validate:"gte=0"`
+	ShowAnswers      bool       `json:"show_answers" form:"show_answers"`
+	MaxAttempts      int        `json:"max_attempts" form:"max_attempts" validate:"gte=0"`
+	AttemptCooldown  int        `json:"attempt_cooldown" form:"attempt_cooldown" validate:"gte=0"`
+	ScorePolicy      string     `json:"score_policy" form:"score_policy" validate:"omitempty,oneof=best latest average"`
+	ShuffleQuestions bool       `json:"shuffle_questions" form:"shuffle_questions"`
+	ShuffleAnswers   bool       `json:"shuffle_answers" form:"shuffle_answers"`
+	PoolSize         int        `json:"pool_size" form:"pool_size" validate:"gte=0"`
+	PoolByTag        bool       `json:"pool_by_tag" form:"pool_by_tag"`
+	Visibility       string     `json:"visibility" form:"visibility" validate:"omitempty,oneof=public unlisted private access_code invite"`
+	AccessCode       string     `json:"access_code" form:"access_code" validate:"required_if=Visibility access_code,max=64"`
+	OpensAt          *time.Time `json:"opens_at" form:"opens_at"`
+	ClosesAt         *time.Time `json:"closes_at" form:"closes_at"`
+}
+
+// UpdateQuiz leaves the settings that are nil unchanged. ClearOpensAt and
+// ClearClosesAt remove the availability window bounds.
+type UpdateQuiz struct {
+	Title            string     `json:"title" form:"title" validate:"required"`
+	Description      string     `json:"description" form:"description"`
+	TimeLimit        *int       `json:"time_limit" form:"time_limit" validate:"omitempty,gte=0"`
+	ShowAnswers      *bool      `json:"show_answers" form:"show_answers"`
+	MaxAttempts      *int       `json:"max_attempts" form:"max_attempts" validate:"omitempty,gte=0"`
+	AttemptCooldown  *int       `json:"attempt_cooldown" form:"attempt_cooldown" validate:"omitempty,gte=0"`
+	ScorePolicy      string     `json:"score_policy" form:"score_policy" validate:"omitempty,oneof=best latest average"`
+	ShuffleQuestions *bool      `json:"shuffle_questions" form:"shuffle_questions"`
+	ShuffleAnswers   *bool      `json:"shuffle_answers" form:"shuffle_answers"`
+	PoolSize         *int       `json:"pool_size" form:"pool_size" validate:"omitempty,gte=0"`
+	PoolByTag        *bool      `json:"pool_by_tag" form:"pool_by_tag"`
+	Visibility       string     `json:"visibility" form:"visibility" validate:"omitempty,oneof=public unlisted private access_code invite"`
+	AccessCode       string     `json:"access_code" form:"access_code" validate:"required_if=Visibility access_code,max=64"`
+	OpensAt          *time.Time `json:"opens_at" form:"opens_at"`
+	ClosesAt         *time.Time `json:"closes_at" form:"closes_at"`
+	ClearOpensAt     bool       `json:"clear_opens_at" form:"clear_opens_at" validate:"excluded_with=OpensAt"`
+	ClearClosesAt    bool       `json:"clear_closes_at" form:"clear_closes_at" validate:"excluded_with=ClosesAt"`
+}
+
+type QuizInvite struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type QuizTree struct {
+	Quiz
+	Image     string         `json:"image"`
+	Questions []QuestionTree `json:"questions" validate:"dive"`
+}
+
+type QuestionTree struct {
+	ID int `json:"id,omitempty"`
+	Question
+	Image   string       `json:"image"`
+	Audio   string       `json:"audio"`
+	Answers []AnswerTree `json:"answers" validate:"dive"`
+}
+
+type AnswerTree struct {
+	ID int `json:"id,omitempty"`
+	Answer
+	Image string `json:"image"`
 }
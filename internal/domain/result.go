@@ -3,3 +3,8 @@ package domain
 type SubmitResult struct {
 	AttemptID int `json:"attempt_id" validate:"required"`
 }
+
+type GradeAnswer struct {
+	Points   float64 `json:"points" validate:"gte=0"`
+	Feedback string  `json:"feedback"`
+}
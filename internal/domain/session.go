@@ -0,0 +1,20 @@
+package domain
+
+type Session struct {
+	QuizID   int `json:"quiz_id" validate:"required"`
+	Duration int `json:"duration" validate:"omitempty,gte=5,lte=300"`
+}
+
+type SessionJoin struct {
+	PIN      string `json:"pin"`
+	Nickname string `json:"nickname"`
+}
+
+type SessionAnswer struct {
+	PIN        string       `json:"pin"`
+	Token      string       `json:"token"`
+	AnswerID   int          `json:"answer_id"`
+	AnswerIDs  []int        `json:"answer_ids"`
+	AnswerText string       `json:"answer_text"`
+	Pairs      []AnswerPair `json:"pairs" validate:"dive"`
+}
@@ -57,3 +57,24 @@ func AdminMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// OptionalAuthMiddleware sets userID when the request carries a valid token and
+// lets anonymous requests through with userID 0.
+func OptionalAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Set("userID", 0)
+
+		token, err := c.Request().Cookie("token")
+
+		if err != nil || token.Value == "" {
+			return next(c)
+		}
+
+		userID, _, err := auth.ParseToken(token.Value)
+		if err == nil {
+			c.Set("userID", userID)
+		}
+
+		return next(c)
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+type QuizAnalytics struct {
+	Started         int                 `json:"started"`
+	Completed       int                 `json:"completed"`
+	MeanScore       float64             `json:"mean_score"`
+	MedianScore     float64             `json:"median_score"`
+	Distribution    []ScoreBucket       `json:"distribution"`
+	AverageDuration float64             `json:"average_duration"`
+	Questions       []QuestionAnalytics `json:"questions"`
+}
+
+type ScoreBucket struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Count int `json:"count"`
+}
+
+type QuestionAnalytics struct {
+	ID             int                   `json:"id"`
+	Title          string                `json:"title"`
+	Type           string                `json:"type"`
+	Answered       int                   `json:"answered"`
+	Difficulty     float64               `json:"difficulty"`
+	Discrimination float64               `json:"discrimination"`
+	Distractors    []DistractorAnalytics `json:"distractors"`
+}
+
+type DistractorAnalytics struct {
+	ID     int     `json:"id"`
+	Text   string  `json:"text"`
+	Picked int     `json:"picked"`
+	Rate   float64 `json:"rate"`
+}
+
+type ResultQuestion struct {
+	ResultID   int `json:"result_id" db:"result_id"`
+	QuestionID int `json:"question_id" db:"question_id"`
+}
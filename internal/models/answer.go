@@ -3,23 +3,38 @@ package models
 type Answer struct {
 	ID         int    `json:"id" db:"id"`
 	Text       string `json:"text" db:"text"`
+	Image      string `json:"image" db:"image"`
 	QuestionID int    `json:"question_id" db:"question_id"`
 	IsCorrect  bool   `json:"is_correct" db:"is_correct"`
 	OrderID    int    `json:"order_id" db:"order_id"`
+	MatchText  string `json:"match_text" db:"match_text"`
+	TextHTML   string `json:"text_html,omitempty" db:"-"`
 }
 
 type AnswerInfo struct {
 	ID         int    `json:"id" db:"id"`
 	Text       string `json:"text" db:"text"`
+	Image      string `json:"image" db:"image"`
 	QuestionID int    `json:"question_id" db:"question_id"`
 	OrderID    int    `json:"order_id" db:"order_id"`
+	MatchText  string `json:"match_text,omitempty" db:"match_text"`
+	TextHTML   string `json:"text_html,omitempty" db:"-"`
 }
 
 type UserAnswer struct {
-	ID         int    `json:"id" db:"id"`
-	UserID     int    `json:"user_id" db:"user_id"`
-	QuestionID int    `json:"question_id" db:"question_id"`
-	AnswerID   int    `json:"answer_id" db:"answer_id"`
-	ResultID   int    `json:"result_id" db:"result_id"`
-	Text       string `json:"text" db:"text"`
+	ID          int      `json:"id" db:"id"`
+	UserID      int      `json:"user_id" db:"user_id"`
+	QuestionID  int      `json:"question_id" db:"question_id"`
+	AnswerID    int      `json:"answer_id" db:"answer_id"`
+	ResultID    int      `json:"result_id" db:"result_id"`
+	Text        string   `json:"text" db:"text"`
+	AnswerText  string   `json:"answer_text" db:"answer_text"`
+	NeedsReview bool     `json:"-" db:"needs_review"`
+	Points      *float64 `json:"points,omitempty" db:"points"`
+	Feedback    string   `json:"feedback,omitempty" db:"feedback"`
+}
+
+type AnswerPair struct {
+	AnswerID  int    `json:"answer_id"`
+	MatchText string `json:"match_text"`
 }
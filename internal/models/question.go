@@ -1,20 +1,39 @@
 package models
 
 type Question struct {
-	ID      int    `json:"id" db:"id"`
-	Title   string `json:"title" db:"title"`
-	Image   string `json:"image" db:"image"`
-	QuizID  int    `json:"quiz_id" db:"quiz_id"`
-	Type    string `json:"type" db:"type"`
-	OrderID int    `json:"order_id" db:"order_id"`
+	ID            int    `json:"id" db:"id"`
+	Title         string `json:"title" db:"title"`
+	Image         string `json:"image" db:"image"`
+	Audio         string `json:"audio" db:"audio"`
+	QuizID        int    `json:"quiz_id" db:"quiz_id"`
+	Type          string `json:"type" db:"type"`
+	OrderID       int    `json:"order_id" db:"order_id"`
+	Scoring       string `json:"scoring" db:"scoring"`
+	Points        int    `json:"points" db:"points"`
+	Tag           string `json:"tag" db:"tag"`
+	MatchMode     string `json:"match_mode" db:"match_mode"`
+	IgnoreAccents bool   `json:"ignore_accents" db:"ignore_accents"`
+	TypoTolerance int    `json:"typo_tolerance" db:"typo_tolerance"`
+	Explanation   string `json:"explanation,omitempty" db:"explanation"`
+	TitleHTML     string `json:"title_html,omitempty" db:"-"`
 }
 
 type QuestionWithAnswers struct {
-	ID      int      `json:"id" db:"id"`
-	Title   string   `json:"title" db:"title"`
-	Image   string   `json:"image" db:"image"`
-	QuizID  int      `json:"quiz_id" db:"quiz_id"`
-	Type    string   `json:"type" db:"type"`
-	OrderID int      `json:"order_id" db:"order_id"`
-	Answers []Answer `json:"answers" db:"answers"`
+	ID              int      `json:"id" db:"id"`
+	Title           string   `json:"title" db:"title"`
+	Image           string   `json:"image" db:"image"`
+	Audio           string   `json:"audio" db:"audio"`
+	QuizID          int      `json:"quiz_id" db:"quiz_id"`
+	Type            string   `json:"type" db:"type"`
+	OrderID         int      `json:"order_id" db:"order_id"`
+	Scoring         string   `json:"scoring" db:"scoring"`
+	Points          int      `json:"points" db:"points"`
+	Tag             string   `json:"tag" db:"tag"`
+	MatchMode       string   `json:"match_mode" db:"match_mode"`
+	IgnoreAccents   bool     `json:"ignore_accents" db:"ignore_accents"`
+	TypoTolerance   int      `json:"typo_tolerance" db:"typo_tolerance"`
+	Explanation     string   `json:"explanation" db:"explanation"`
+	TitleHTML       string   `json:"title_html,omitempty" db:"-"`
+	ExplanationHTML string   `json:"explanation_html,omitempty" db:"-"`
+	Answers         []Answer `json:"answers" db:"answers"`
 }
@@ -3,12 +3,43 @@ package models
 import "time"
 
 type Quiz struct {
-	ID          int       `json:"id" db:"id" redis:"id"`
-	Title       string    `json:"title" db:"title" redis:"title"`
-	Description string    `json:"description" db:"description" redis:"description"`
-	Image       string    `json:"image" db:"image" redis:"image"`
-	UserID      int       `json:"user_id" db:"user_id" redis:"user_id"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at" redis:"created_at"`
+	ID               int        `json:"id" db:"id" redis:"id"`
+	Title            string     `json:"title" db:"title" redis:"title"`
+	Description      string     `json:"description" db:"description" redis:"description"`
+	Image            string     `json:"image" db:"image" redis:"image"`
+	UserID           int        `json:"user_id" db:"user_id" redis:"user_id"`
+	TimeLimit        int        `json:"time_limit" db:"time_limit" redis:"time_limit"`
+	ShowAnswers      bool       `json:"show_answers" db:"show_answers" redis:"show_answers"`
+	MaxAttempts      int        `json:"max_attempts" db:"max_attempts" redis:"max_attempts"`
+	AttemptCooldown  int        `json:"attempt_cooldown" db:"attempt_cooldown" redis:"attempt_cooldown"`
+	ScorePolicy      string     `json:"score_policy" db:"score_policy" redis:"score_policy"`
+	ShuffleQuestions bool       `json:"shuffle_questions" db:"shuffle_questions" redis:"shuffle_questions"`
+	ShuffleAnswers   bool       `json:"shuffle_answers" db:"shuffle_answers" redis:"shuffle_answers"`
+	PoolSize         int        `json:"pool_size" db:"pool_size" redis:"pool_size"`
+	PoolByTag        bool       `json:"pool_by_tag" db:"pool_by_tag" redis:"pool_by_tag"`
+	Visibility       string     `json:"visibility" db:"visibility" redis:"visibility"`
+	AccessCode       string     `json:"access_code,omitempty" db:"access_code" redis:"access_code"`
+	OpensAt          *time.Time `json:"opens_at" db:"opens_at" redis:"opens_at"`
+	ClosesAt         *time.Time `json:"closes_at" db:"closes_at" redis:"closes_at"`
+	Status           string     `json:"status" db:"status" redis:"status"`
+	VersionID        *int       `json:"version_id" db:"version_id" redis:"version_id"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at" redis:"created_at"`
+}
+
+type QuizVersion struct {
+	ID        int                   `json:"id" db:"id"`
+	QuizID    int                   `json:"quiz_id" db:"quiz_id"`
+	Version   int                   `json:"version" db:"version"`
+	Questions []QuestionWithAnswers `json:"questions" db:"-"`
+	CreatedAt time.Time             `json:"created_at" db:"created_at"`
+}
+
+type QuizInvite struct {
+	QuizID    int       `json:"quiz_id" db:"quiz_id"`
+	UserID    int       `json:"user_id" db:"user_id"`
+	Username  string    `json:"username" db:"username"`
+	Email     string    `json:"email" db:"email"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
 type QuizInfo struct {
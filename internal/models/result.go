@@ -3,26 +3,111 @@ package models
 import "time"
 
 type Result struct {
-	ID          int       `json:"id" db:"id"`
-	UserID      int       `json:"user_id" db:"user_id"`
-	QuizID      int       `json:"quiz_id" db:"quiz_id"`
-	Score       int       `json:"score" db:"score"`
-	IsCompleted bool      `json:"is_completed" db:"is_completed"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	ID            int        `json:"id" db:"id"`
+	UserID        int        `json:"user_id" db:"user_id"`
+	QuizID        int        `json:"quiz_id" db:"quiz_id"`
+	Score         float64    `json:"score" db:"score"`
+	MaxScore      float64    `json:"max_score" db:"max_score"`
+	Percentage    float64    `json:"percentage" db:"percentage"`
+	IsCompleted   bool       `json:"is_completed" db:"is_completed"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt     *time.Time `json:"expires_at" db:"expires_at"`
+	SubmittedAt   *time.Time `json:"submitted_at" db:"submitted_at"`
+	VersionID     *int       `json:"version_id" db:"version_id"`
+	PendingReview bool       `json:"pending_review" db:"pending_review"`
+}
+
+type Attempt struct {
+	ID        int        `json:"id"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	TimeLeft  *int       `json:"time_left"`
+}
+
+type CurrentAttempt struct {
+	Attempt
+	Answers []UserAnswer `json:"answers"`
 }
 
 type UsersResult struct {
-	ID        int       `json:"id" db:"id"`
-	UserID    int       `json:"user_id" db:"user_id"`
-	Username  string    `json:"username" db:"username"`
-	Avatar    string    `json:"avatar" db:"avatar"`
-	Score     int       `json:"score" db:"score"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID            int        `json:"id" db:"id"`
+	UserID        int        `json:"user_id" db:"user_id"`
+	Username      string     `json:"username" db:"username"`
+	Avatar        string     `json:"avatar" db:"avatar"`
+	Score         float64    `json:"score" db:"score"`
+	MaxScore      float64    `json:"max_score" db:"max_score"`
+	Percentage    float64    `json:"percentage" db:"percentage"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	SubmittedAt   *time.Time `json:"submitted_at" db:"submitted_at"`
+	Duration      *int       `json:"duration" db:"duration"`
+	Rank          int        `json:"rank,omitempty" db:"rank"`
+	PendingReview bool       `json:"pending_review,omitempty" db:"pending_review"`
+}
+
+type Leaderboard struct {
+	Total      int           `json:"total"`
+	TotalPages int           `json:"total_pages"`
+	Page       int           `json:"page"`
+	Size       int           `json:"size"`
+	Results    []UsersResult `json:"results"`
+	Me         *UsersResult  `json:"me"`
 }
 
 type UserResult struct {
 	Quiz           Quiz      `json:"quiz" db:"quiz"`
-	Score          int       `json:"score" db:"score"`
+	Score          float64   `json:"score" db:"score"`
+	MaxScore       float64   `json:"max_score" db:"max_score"`
+	Percentage     float64   `json:"percentage" db:"percentage"`
 	QuestionsCount int       `json:"questions_count" db:"questions_count"`
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 }
+
+type ResultExport struct {
+	ResultID    int        `db:"result_id"`
+	Username    string     `db:"username"`
+	Email       string     `db:"email"`
+	Score       float64    `db:"score"`
+	MaxScore    float64    `db:"max_score"`
+	Percentage  float64    `db:"percentage"`
+	CreatedAt   time.Time  `db:"created_at"`
+	SubmittedAt *time.Time `db:"submitted_at"`
+	Status      string     `db:"status"`
+	VersionID   *int       `db:"version_id"`
+	QuestionID  *int       `db:"question_id"`
+	AnswerID    int        `db:"answer_id"`
+	Text        string     `db:"text"`
+	Answers     map[int][]UserAnswer
+}
+
+type ResultReview struct {
+	Result    Result           `json:"result"`
+	Questions []QuestionReview `json:"questions"`
+}
+
+type QuestionReview struct {
+	ID              int          `json:"id"`
+	Title           string       `json:"title"`
+	TitleHTML       string       `json:"title_html,omitempty"`
+	Image           string       `json:"image"`
+	Audio           string       `json:"audio"`
+	Type            string       `json:"type"`
+	Points          int          `json:"points"`
+	Earned          float64      `json:"earned"`
+	IsCorrect       bool         `json:"is_correct"`
+	UserAnswers     []UserAnswer `json:"user_answers"`
+	CorrectAnswers  []Answer     `json:"correct_answers,omitempty"`
+	Explanation     string       `json:"explanation,omitempty"`
+	ExplanationHTML string       `json:"explanation_html,omitempty"`
+}
+
+type GradingItem struct {
+	ID            int        `json:"id" db:"id"`
+	ResultID      int        `json:"result_id" db:"result_id"`
+	UserID        int        `json:"user_id" db:"user_id"`
+	Username      string     `json:"username" db:"username"`
+	QuestionID    int        `json:"question_id" db:"question_id"`
+	QuestionTitle string     `json:"question_title" db:"-"`
+	MaxPoints     int        `json:"max_points" db:"-"`
+	Text          string     `json:"text" db:"text"`
+	SubmittedAt   *time.Time `json:"submitted_at" db:"submitted_at"`
+	VersionID     *int       `json:"-" db:"version_id"`
+}
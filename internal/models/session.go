@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+type Session struct {
+	PIN           string     `json:"pin"`
+	QuizID        int        `json:"quiz_id"`
+	HostID        int        `json:"host_id"`
+	Status        string     `json:"status"`
+	Duration      int        `json:"duration"`
+	QuestionIDs   []int      `json:"question_ids"`
+	QuestionIndex int        `json:"question_index"`
+	EndsAt        *time.Time `json:"ends_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type SessionPlayer struct {
+	Token    string `json:"token"`
+	Nickname string `json:"nickname"`
+}
+
+type SessionAnswer struct {
+	AnswerIDs  []int        `json:"answer_ids"`
+	Text       string       `json:"text"`
+	Pairs      []AnswerPair `json:"pairs,omitempty"`
+	AnsweredAt time.Time    `json:"answered_at"`
+}
+
+type SessionQuestion struct {
+	Index    int          `json:"index"`
+	Total    int          `json:"total"`
+	Question Question     `json:"question"`
+	Answers  []AnswerInfo `json:"answers"`
+	EndsAt   time.Time    `json:"ends_at"`
+}
+
+type SessionAnswerStats struct {
+	ID        int    `json:"id"`
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"is_correct"`
+	Count     int    `json:"count"`
+}
+
+type SessionQuestionStats struct {
+	Index           int                  `json:"index"`
+	QuestionID      int                  `json:"question_id"`
+	Answered        int                  `json:"answered"`
+	Correct         int                  `json:"correct"`
+	Answers         []SessionAnswerStats `json:"answers"`
+	Explanation     string               `json:"explanation,omitempty"`
+	ExplanationHTML string               `json:"explanation_html,omitempty"`
+}
+
+type SessionScore struct {
+	Nickname string  `json:"nickname"`
+	Score    float64 `json:"score"`
+}
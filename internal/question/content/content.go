@@ -0,0 +1,37 @@
+package content
+
+import (
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/blazee5/quizmaster-backend/lib/markdown"
+)
+
+// Questions fills the rendered title of questions shown to takers.
+// Explanations are left out because takers only see them after answering.
+func Questions(questions []models.Question) {
+	for i := range questions {
+		questions[i].TitleHTML = markdown.Render(questions[i].Title)
+	}
+}
+
+// QuestionsWithAnswers fills the rendered title, explanation and answers of questions.
+func QuestionsWithAnswers(questions []models.QuestionWithAnswers) {
+	for i := range questions {
+		questions[i].TitleHTML = markdown.Render(questions[i].Title)
+		questions[i].ExplanationHTML = markdown.Render(questions[i].Explanation)
+		Answers(questions[i].Answers)
+	}
+}
+
+// Answers fills the rendered text of answers.
+func Answers(answers []models.Answer) {
+	for i := range answers {
+		answers[i].TextHTML = markdown.Render(answers[i].Text)
+	}
+}
+
+// AnswersInfo fills the rendered text of answers shown to takers.
+func AnswersInfo(answers []models.AnswerInfo) {
+	for i := range answers {
+		answers[i].TextHTML = markdown.Render(answers[i].Text)
+	}
+}
@@ -0,0 +1,24 @@
+package content
+
+import (
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestQuestionsWithAnswers(t *testing.T) {
+	t.Parallel()
+
+	questions := []models.QuestionWithAnswers{{
+		Title:       "What does `echo $HOME` print? <script>alert(1)</script>",
+		Explanation: "It expands the variable, see $$a_1 < b$$",
+		Answers:     []models.Answer{{Text: "**The** home directory"}, {Text: "<img src=x onerror=alert(1)>"}},
+	}}
+
+	QuestionsWithAnswers(questions)
+
+	require.Equal(t, "<p>What does <code>echo $HOME</code> print? alert(1)</p>\n", questions[0].TitleHTML)
+	require.Equal(t, "<p>It expands the variable, see <span class=\"math display\">a_1 &lt; b</span></p>\n", questions[0].ExplanationHTML)
+	require.Equal(t, "<p><strong>The</strong> home directory</p>\n", questions[0].Answers[0].TextHTML)
+	require.NotContains(t, questions[0].Answers[1].TextHTML, "onerror")
+}
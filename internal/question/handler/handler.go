@@ -98,6 +98,7 @@ func (h *Handler) GetQuizQuestions(c echo.Context) error {
 	ctx, span := h.tracer.Start(c.Request().Context(), "question.GetQuizQuestions")
 	defer span.End()
 
+	userID := c.Get("userID").(int)
 	id, err := strconv.Atoi(c.Param("id"))
 
 	if err != nil {
@@ -106,7 +107,7 @@ func (h *Handler) GetQuizQuestions(c echo.Context) error {
 		})
 	}
 
-	questions, err := h.service.GetQuestionsByID(ctx, id)
+	questions, err := h.service.GetQuestionsByID(ctx, id, userID, c.Request().Header.Get("X-Access-Code"))
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return c.JSON(http.StatusNotFound, echo.Map{
@@ -114,6 +115,18 @@ func (h *Handler) GetQuizQuestions(c echo.Context) error {
 		})
 	}
 
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrAccessCodeRequired) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "access code required",
+		})
+	}
+
 	if err != nil {
 		h.log.Infof("error while get questions by quiz id: %s", err)
 
@@ -213,6 +226,12 @@ func (h *Handler) UpdateQuestion(c echo.Context) error {
 		})
 	}
 
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
 	if err != nil {
 		h.log.Infof("error while update question: %s", err)
 
@@ -388,6 +407,118 @@ func (h *Handler) DeleteImage(c echo.Context) error {
 	return c.String(http.StatusOK, "OK")
 }
 
+func (h *Handler) UploadAudio(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "question.UploadAudio")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	questionID, err := strconv.Atoi(c.Param("questionID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid question id",
+		})
+	}
+
+	file, err := c.FormFile("audio")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "file is required",
+		})
+	}
+
+	err = h.service.UploadAudio(ctx, questionID, userID, quizID, file)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrInvalidAudio) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid audio",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while upload question audio: %v", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.String(http.StatusOK, "OK")
+}
+
+func (h *Handler) DeleteAudio(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "question.DeleteAudio")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	questionID, err := strconv.Atoi(c.Param("questionID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid question id",
+		})
+	}
+
+	err = h.service.DeleteAudio(ctx, questionID, userID, quizID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while delete question audio: %v", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.String(http.StatusOK, "OK")
+}
+
 func (h *Handler) ChangeOrder(c echo.Context) error {
 	ctx, span := h.tracer.Start(c.Request().Context(), "question.ChangeOrder")
 	defer span.End()
@@ -4,6 +4,7 @@ import (
 	questionRepo "github.com/blazee5/quizmaster-backend/internal/question/repository"
 	questionService "github.com/blazee5/quizmaster-backend/internal/question/service"
 	quizRepo "github.com/blazee5/quizmaster-backend/internal/quiz/repository"
+	resultRepo "github.com/blazee5/quizmaster-backend/internal/result/repository"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
 	"github.com/minio/minio-go/v7"
@@ -15,15 +16,18 @@ func InitQuestionRoutes(questionGroup *echo.Group, log *zap.SugaredLogger, db *s
 	repos := questionRepo.NewRepository(db, tracer)
 	awsRepos := questionRepo.NewAWSRepository(awsClient)
 	quizRepos := quizRepo.NewRepository(db, tracer)
-	services := questionService.NewService(log, repos, quizRepos, awsRepos, tracer)
+	resultRepos := resultRepo.NewRepository(db, tracer)
+	services := questionService.NewService(log, repos, quizRepos, resultRepos, awsRepos, tracer)
 	handlers := NewHandler(log, services, tracer)
 
 	questionGroup.POST("", handlers.CreateQuestion)
 	questionGroup.POST("/:questionID/image", handlers.UploadImage)
+	questionGroup.POST("/:questionID/audio", handlers.UploadAudio)
 	questionGroup.GET("", handlers.GetQuizQuestions)
 	questionGroup.GET("/author", handlers.GetQuestionsAuthor)
 	questionGroup.PUT("/:questionID", handlers.UpdateQuestion)
 	questionGroup.PUT("/order", handlers.ChangeOrder)
 	questionGroup.DELETE("/:questionID", handlers.DeleteQuestion)
 	questionGroup.DELETE("/:questionID/image", handlers.DeleteImage)
+	questionGroup.DELETE("/:questionID/audio", handlers.DeleteAudio)
 }
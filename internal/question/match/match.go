@@ -0,0 +1,167 @@
+package match
+
+import (
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	Exact      = types.MatchExact
+	Normalized = types.MatchNormalized
+	Regex      = types.MatchRegex
+	Numeric    = types.MatchNumeric
+)
+
+// Input reports whether the text typed by a taker matches one of the accepted
+// answers of an input question, following the question match mode:
+//   - exact compares case-insensitively after trimming the ends;
+//   - normalized also collapses whitespace and ignores punctuation;
+//   - regex treats every accepted answer as a case-insensitive pattern for the whole text;
+//   - numeric compares the values, so "0.5", "0,5" and "1/2" are equal.
+//
+// IgnoreAccents and TypoTolerance apply to the exact and normalized modes,
+// IgnoreAccents to regex as well.
+func Input(question models.QuestionWithAnswers, text string) bool {
+	for _, answer := range question.Answers {
+		if matchAnswer(question, answer.Text, text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAnswer(question models.QuestionWithAnswers, accepted, text string) bool {
+	switch question.MatchMode {
+	case Regex:
+		re, err := types.CompilePattern(accepted)
+
+		if err != nil {
+			return false
+		}
+
+		text = strings.TrimSpace(text)
+
+		if question.IgnoreAccents {
+			text = RemoveAccents(text)
+		}
+
+		return re.MatchString(text)
+	case Numeric:
+		expected, err := types.ParseRational(accepted)
+
+		if err != nil {
+			return false
+		}
+
+		actual, err := types.ParseRational(text)
+
+		if err != nil {
+			return false
+		}
+
+		return math.Abs(expected-actual) <= 1e-9*math.Max(1, math.Max(math.Abs(expected), math.Abs(actual)))
+	}
+
+	accepted = Normalize(accepted, question.MatchMode, question.IgnoreAccents)
+	text = Normalize(text, question.MatchMode, question.IgnoreAccents)
+
+	if accepted == text {
+		return true
+	}
+
+	if question.TypoTolerance <= 0 {
+		return false
+	}
+
+	acceptedLength, textLength := utf8.RuneCountInString(accepted), utf8.RuneCountInString(text)
+
+	// the distance is at least the length difference, so long texts are
+	// rejected without running the quadratic comparison
+	if abs(acceptedLength-textLength) > question.TypoTolerance {
+		return false
+	}
+
+	distance := Levenshtein(accepted, text)
+
+	return distance <= question.TypoTolerance && distance < acceptedLength
+}
+
+// Normalize prepares a text for comparison in the exact and normalized modes.
+func Normalize(text, mode string, ignoreAccents bool) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	if ignoreAccents {
+		text = RemoveAccents(text)
+	}
+
+	if mode != Normalized {
+		return text
+	}
+
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return -1
+		}
+
+		return r
+	}, text)
+
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// RemoveAccents strips combining marks, so "café" becomes "cafe".
+func RemoveAccents(text string) string {
+	result, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+
+	if err != nil {
+		return text
+	}
+
+	return result
+}
+
+// Levenshtein returns the number of single rune insertions, deletions and
+// substitutions needed to turn a into b.
+func Levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
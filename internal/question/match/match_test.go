@@ -0,0 +1,68 @@
+package match
+
+import (
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		mode          string
+		ignoreAccents bool
+		typoTolerance int
+		accepted      string
+		text          string
+		expected      bool
+	}{
+		{"exact ignores case and ends", Exact, false, 0, "Paris", " paris ", true},
+		{"exact keeps punctuation", Exact, false, 0, "New York", "New-York", false},
+		{"normalized collapses whitespace", Normalized, false, 0, "New York", "new   york", true},
+		{"normalized ignores punctuation", Normalized, false, 0, "Rock 'n' roll", "rock n roll!", true},
+		{"accents are significant by default", Exact, false, 0, "café", "cafe", false},
+		{"accent insensitive", Exact, true, 0, "Crème brûlée", "creme brulee", true},
+		{"typo within tolerance", Exact, false, 2, "photosynthesis", "fotosynthesis", true},
+		{"typo over tolerance", Exact, false, 1, "photosynthesis", "fotosyntesis", false},
+		{"tolerance never accepts everything", Exact, false, 3, "ox", "an", false},
+		{"length difference over tolerance", Exact, false, 2, "cat", "catalogue", false},
+		{"length difference within tolerance", Exact, false, 2, "cat", "cats", true},
+		{"regex", Regex, false, 0, `colou?r`, "Color", true},
+		{"regex matches whole text", Regex, false, 0, `colou?r`, "colors", false},
+		{"regex with accents", Regex, true, 0, `ete`, "été", true},
+		{"numeric fraction", Numeric, false, 0, "0.5", "1/2", true},
+		{"numeric decimal comma", Numeric, false, 0, "3/4", "0,75", true},
+		{"numeric mismatch", Numeric, false, 0, "0.5", "0.51", false},
+		{"numeric garbage", Numeric, false, 0, "0.5", "half", false},
+		{"unset mode is exact", "", false, 0, "Rome", "rome", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			question := models.QuestionWithAnswers{
+				Type:          "input",
+				MatchMode:     tt.mode,
+				IgnoreAccents: tt.ignoreAccents,
+				TypoTolerance: tt.typoTolerance,
+				Answers:       []models.Answer{{Text: tt.accepted}},
+			}
+
+			require.Equal(t, tt.expected, Input(question, tt.text))
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, 0, Levenshtein("", ""))
+	require.Equal(t, 3, Levenshtein("kitten", "sitting"))
+	require.Equal(t, 1, Levenshtein("ёж", "еж"))
+	require.Equal(t, 4, Levenshtein("", "test"))
+}
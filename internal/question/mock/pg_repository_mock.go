@@ -0,0 +1,214 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/question/pg_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/question/pg_repository.go -destination internal/question/mock/pg_repository_mock.go
+//
+// Package mock_question is a generated GoMock package.
+package mock_question
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/blazee5/quizmaster-backend/internal/domain"
+	models "github.com/blazee5/quizmaster-backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ChangeOrder mocks base method.
+func (m *MockRepository) ChangeOrder(ctx context.Context, input domain.QuestionOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeOrder", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeOrder indicates an expected call of ChangeOrder.
+func (mr *MockRepositoryMockRecorder) ChangeOrder(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeOrder", reflect.TypeOf((*MockRepository)(nil).ChangeOrder), ctx, input)
+}
+
+// CreateQuestion mocks base method.
+func (m *MockRepository) CreateQuestion(ctx context.Context, quizID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuestion", ctx, quizID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuestion indicates an expected call of CreateQuestion.
+func (mr *MockRepositoryMockRecorder) CreateQuestion(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuestion", reflect.TypeOf((*MockRepository)(nil).CreateQuestion), ctx, quizID)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteAudio mocks base method.
+func (m *MockRepository) DeleteAudio(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAudio", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAudio indicates an expected call of DeleteAudio.
+func (mr *MockRepositoryMockRecorder) DeleteAudio(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAudio", reflect.TypeOf((*MockRepository)(nil).DeleteAudio), ctx, id)
+}
+
+// DeleteImage mocks base method.
+func (m *MockRepository) DeleteImage(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockRepositoryMockRecorder) DeleteImage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockRepository)(nil).DeleteImage), ctx, id)
+}
+
+// GetMediaFiles mocks base method.
+func (m *MockRepository) GetMediaFiles(ctx context.Context, id int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaFiles", ctx, id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaFiles indicates an expected call of GetMediaFiles.
+func (mr *MockRepositoryMockRecorder) GetMediaFiles(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaFiles", reflect.TypeOf((*MockRepository)(nil).GetMediaFiles), ctx, id)
+}
+
+// GetQuestionByID mocks base method.
+func (m *MockRepository) GetQuestionByID(ctx context.Context, id int) (models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionByID", ctx, id)
+	ret0, _ := ret[0].(models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionByID indicates an expected call of GetQuestionByID.
+func (mr *MockRepositoryMockRecorder) GetQuestionByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionByID", reflect.TypeOf((*MockRepository)(nil).GetQuestionByID), ctx, id)
+}
+
+// GetQuestionsAuthor mocks base method.
+func (m *MockRepository) GetQuestionsAuthor(ctx context.Context, quizID int) ([]models.QuestionWithAnswers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionsAuthor", ctx, quizID)
+	ret0, _ := ret[0].([]models.QuestionWithAnswers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionsAuthor indicates an expected call of GetQuestionsAuthor.
+func (mr *MockRepositoryMockRecorder) GetQuestionsAuthor(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionsAuthor", reflect.TypeOf((*MockRepository)(nil).GetQuestionsAuthor), ctx, quizID)
+}
+
+// GetQuestionsByQuizID mocks base method.
+func (m *MockRepository) GetQuestionsByQuizID(ctx context.Context, quizID int) ([]models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionsByQuizID", ctx, quizID)
+	ret0, _ := ret[0].([]models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionsByQuizID indicates an expected call of GetQuestionsByQuizID.
+func (mr *MockRepositoryMockRecorder) GetQuestionsByQuizID(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionsByQuizID", reflect.TypeOf((*MockRepository)(nil).GetQuestionsByQuizID), ctx, quizID)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, id int, input domain.Question) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, id, input)
+}
+
+// UploadAudio mocks base method.
+func (m *MockRepository) UploadAudio(ctx context.Context, id int, filename string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAudio", ctx, id, filename)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadAudio indicates an expected call of UploadAudio.
+func (mr *MockRepositoryMockRecorder) UploadAudio(ctx, id, filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAudio", reflect.TypeOf((*MockRepository)(nil).UploadAudio), ctx, id, filename)
+}
+
+// UploadImage mocks base method.
+func (m *MockRepository) UploadImage(ctx context.Context, id int, filename string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", ctx, id, filename)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockRepositoryMockRecorder) UploadImage(ctx, id, filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockRepository)(nil).UploadImage), ctx, id, filename)
+}
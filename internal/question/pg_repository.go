@@ -15,5 +15,8 @@ type Repository interface {
 	Delete(ctx context.Context, id int) error
 	UploadImage(ctx context.Context, id int, filename string) error
 	DeleteImage(ctx context.Context, id int) error
+	UploadAudio(ctx context.Context, id int, filename string) error
+	DeleteAudio(ctx context.Context, id int) error
+	GetMediaFiles(ctx context.Context, id int) ([]string, error)
 	ChangeOrder(ctx context.Context, input domain.QuestionOrder) error
 }
@@ -53,7 +53,8 @@ func (repo *Repository) GetQuestionsByQuizID(ctx context.Context, quizID int) ([
 	questions := make([]models.Question, 0)
 
 	err := repo.db.SelectContext(ctx, &questions,
-		`SELECT q.id, q.title, q.image, q.quiz_id, q.type, q.order_id FROM questions q
+		`SELECT q.id, q.title, q.image, q.audio, q.quiz_id, q.type, q.order_id, q.scoring, q.points, q.tag,
+		q.match_mode, q.ignore_accents, q.typo_tolerance FROM questions q
 		WHERE quiz_id = $1
 		ORDER BY q.order_id ASC`, quizID)
 
@@ -71,7 +72,8 @@ func (repo *Repository) GetQuestionsAuthor(ctx context.Context, quizID int) ([]m
 	questions := make([]models.QuestionWithAnswers, 0)
 
 	rows, err := repo.db.QueryxContext(ctx,
-		`SELECT q.id, q.title, q.image, q.quiz_id, q.type, q.order_id, a.id, a.text, a.is_correct, a.question_id, a.order_id
+		`SELECT q.id, q.title, q.image, q.audio, q.quiz_id, q.type, q.order_id, q.scoring, q.points, q.tag,
+		q.match_mode, q.ignore_accents, q.typo_tolerance, q.explanation, a.id, a.text, a.is_correct, a.question_id, a.order_id, a.match_text, a.image
 		FROM questions q
 		LEFT JOIN answers a ON q.id = a.question_id
 		WHERE q.quiz_id = $1
@@ -90,8 +92,9 @@ func (repo *Repository) GetQuestionsAuthor(ctx context.Context, quizID int) ([]m
 		a := models.Answer{}
 
 		_ = rows.Scan(
-			&q.ID, &q.Title, &q.Image, &q.QuizID, &q.Type, &q.OrderID,
-			&a.ID, &a.Text, &a.IsCorrect, &a.QuestionID, &a.OrderID,
+			&q.ID, &q.Title, &q.Image, &q.Audio, &q.QuizID, &q.Type, &q.OrderID, &q.Scoring, &q.Points, &q.Tag,
+			&q.MatchMode, &q.IgnoreAccents, &q.TypoTolerance, &q.Explanation,
+			&a.ID, &a.Text, &a.IsCorrect, &a.QuestionID, &a.OrderID, &a.MatchText, &a.Image,
 		)
 
 		if existingQuestion, ok := questionMap[q.ID]; ok {
@@ -119,9 +122,16 @@ func (repo *Repository) Update(ctx context.Context, id int, input domain.Questio
 
 	err := repo.db.QueryRowxContext(ctx, `UPDATE questions
 		SET title = $1,
-		    type = COALESCE(NULLIF($2, ''), type)
-		WHERE id = $3`,
-		input.Title, input.Type, id).Err()
+		    type = COALESCE(NULLIF($2, ''), type),
+		    scoring = COALESCE(NULLIF($3, ''), scoring),
+		    points = COALESCE($4, points),
+		    tag = $5,
+		    match_mode = COALESCE(NULLIF($6, ''), match_mode),
+		    ignore_accents = $7,
+		    typo_tolerance = $8,
+		    explanation = $9
+		WHERE id = $10`,
+		input.Title, input.Type, input.Scoring, input.Points, input.Tag, input.MatchMode, input.IgnoreAccents, input.TypoTolerance, input.Explanation, id).Err()
 
 	if err != nil {
 		return err
@@ -143,6 +153,24 @@ func (repo *Repository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
+// GetMediaFiles returns the names of the image and audio objects of a question and the images of its answers.
+func (repo *Repository) GetMediaFiles(ctx context.Context, id int) ([]string, error) {
+	ctx, span := repo.tracer.Start(ctx, "questionRepo.GetMediaFiles")
+	defer span.End()
+
+	fileNames := make([]string, 0)
+
+	err := repo.db.SelectContext(ctx, &fileNames, `SELECT image FROM questions WHERE id = $1 AND image <> ''
+		UNION ALL SELECT audio FROM questions WHERE id = $1 AND audio <> ''
+		UNION ALL SELECT image FROM answers WHERE question_id = $1 AND image <> ''`, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fileNames, nil
+}
+
 func (repo *Repository) UploadImage(ctx context.Context, id int, filename string) error {
 	ctx, span := repo.tracer.Start(ctx, "questionRepo.UploadImage")
 	defer span.End()
@@ -169,6 +197,32 @@ func (repo *Repository) DeleteImage(ctx context.Context, id int) error {
 	return nil
 }
 
+func (repo *Repository) UploadAudio(ctx context.Context, id int, filename string) error {
+	ctx, span := repo.tracer.Start(ctx, "questionRepo.UploadAudio")
+	defer span.End()
+
+	err := repo.db.QueryRowxContext(ctx, "UPDATE questions SET audio = $1 WHERE id = $2", filename, id).Err()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *Repository) DeleteAudio(ctx context.Context, id int) error {
+	ctx, span := repo.tracer.Start(ctx, "questionRepo.DeleteAudio")
+	defer span.End()
+
+	err := repo.db.QueryRowxContext(ctx, "UPDATE questions SET audio = '' WHERE id = $1", id).Err()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (repo *Repository) ChangeOrder(ctx context.Context, input domain.QuestionOrder) error {
 	ctx, span := repo.tracer.Start(ctx, "questionRepo.ChangeOrder")
 	defer span.End()
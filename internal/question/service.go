@@ -9,11 +9,13 @@ import (
 
 type Service interface {
 	Create(ctx context.Context, userID, quizID int) (int, error)
-	GetQuestionsByID(ctx context.Context, id int) ([]models.Question, error)
+	GetQuestionsByID(ctx context.Context, id, userID int, accessCode string) ([]models.Question, error)
 	GetQuestionsAuthor(ctx context.Context, quizID, userID int) ([]models.QuestionWithAnswers, error)
 	Update(ctx context.Context, id, userID, quizID int, input domain.Question) error
 	Delete(ctx context.Context, id, userID, quizID int) error
 	UploadImage(ctx context.Context, id, userID, quizID int, fileHeader *multipart.FileHeader) error
 	DeleteImage(ctx context.Context, id, userID, quizID int) error
+	UploadAudio(ctx context.Context, id, userID, quizID int, fileHeader *multipart.FileHeader) error
+	DeleteAudio(ctx context.Context, id, userID, quizID int) error
 	ChangeOrder(ctx context.Context, userId, quizId int, input domain.QuestionOrder) error
 }
@@ -2,12 +2,21 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	"github.com/blazee5/quizmaster-backend/internal/models"
 	questionRepo "github.com/blazee5/quizmaster-backend/internal/question"
+	"github.com/blazee5/quizmaster-backend/internal/question/content"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
 	quizRepo "github.com/blazee5/quizmaster-backend/internal/quiz"
+	"github.com/blazee5/quizmaster-backend/internal/quiz/media"
+	"github.com/blazee5/quizmaster-backend/internal/quiz/visibility"
+	resultRepo "github.com/blazee5/quizmaster-backend/internal/result"
 	"github.com/blazee5/quizmaster-backend/lib/files"
 	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/blazee5/quizmaster-backend/lib/random"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -15,15 +24,16 @@ import (
 )
 
 type Service struct {
-	log      *zap.SugaredLogger
-	repo     questionRepo.Repository
-	quizRepo quizRepo.Repository
-	awsRepo  questionRepo.AWSRepository
-	tracer   trace.Tracer
+	log        *zap.SugaredLogger
+	repo       questionRepo.Repository
+	quizRepo   quizRepo.Repository
+	resultRepo resultRepo.Repository
+	awsRepo    questionRepo.AWSRepository
+	tracer     trace.Tracer
 }
 
-func NewService(log *zap.SugaredLogger, repo questionRepo.Repository, quizRepo quizRepo.Repository, awsRepo questionRepo.AWSRepository, tracer trace.Tracer) *Service {
-	return &Service{log: log, repo: repo, quizRepo: quizRepo, awsRepo: awsRepo, tracer: tracer}
+func NewService(log *zap.SugaredLogger, repo questionRepo.Repository, quizRepo quizRepo.Repository, resultRepo resultRepo.Repository, awsRepo questionRepo.AWSRepository, tracer trace.Tracer) *Service {
+	return &Service{log: log, repo: repo, quizRepo: quizRepo, resultRepo: resultRepo, awsRepo: awsRepo, tracer: tracer}
 }
 
 func (s *Service) Create(ctx context.Context, userID, quizID int) (int, error) {
@@ -55,11 +65,11 @@ func (s *Service) Create(ctx context.Context, userID, quizID int) (int, error) {
 	return id, nil
 }
 
-func (s *Service) GetQuestionsByID(ctx context.Context, id int) ([]models.Question, error) {
+func (s *Service) GetQuestionsByID(ctx context.Context, id, userID int, accessCode string) ([]models.Question, error) {
 	ctx, span := s.tracer.Start(ctx, "questionService.GetQuestionsByID")
 	defer span.End()
 
-	questions, err := s.repo.GetQuestionsByQuizID(ctx, id)
+	quiz, err := s.quizRepo.GetByID(ctx, id)
 
 	if err != nil {
 		span.RecordError(err)
@@ -68,6 +78,122 @@ func (s *Service) GetQuestionsByID(ctx context.Context, id int) ([]models.Questi
 		return nil, err
 	}
 
+	attempt, err := s.resultRepo.GetByUserID(ctx, userID, id)
+	hasAttempt := err == nil
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	if !hasAttempt {
+		if err := visibility.Check(ctx, s.quizRepo, quiz, userID, accessCode); err != nil {
+			return nil, err
+		}
+	}
+
+	versionID := quiz.VersionID
+
+	if hasAttempt && attempt.VersionID != nil {
+		versionID = attempt.VersionID
+	}
+
+	questions, err := s.getPublishedQuestions(ctx, quiz, userID, versionID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	content.Questions(questions)
+
+	if !quiz.ShuffleQuestions && quiz.PoolSize == 0 {
+		return questions, nil
+	}
+
+	if !hasAttempt {
+		if quiz.PoolSize > 0 {
+			return []models.Question{}, nil
+		}
+
+		return questions, nil
+	}
+
+	questionIDs, err := s.resultRepo.GetResultQuestions(ctx, attempt.ID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	if len(questionIDs) > 0 {
+		pool := make(map[int]bool, len(questionIDs))
+
+		for _, questionID := range questionIDs {
+			pool[questionID] = true
+		}
+
+		drawn := make([]models.Question, 0, len(questionIDs))
+
+		for _, question := range questions {
+			if pool[question.ID] {
+				drawn = append(drawn, question)
+			}
+		}
+
+		questions = drawn
+	}
+
+	if quiz.ShuffleQuestions {
+		random.Shuffle(questions, attempt.ID)
+	}
+
+	return questions, nil
+}
+
+// getPublishedQuestions returns the questions of the given quiz version. A quiz
+// that was never published only shows its draft questions to the owner.
+func (s *Service) getPublishedQuestions(ctx context.Context, quiz models.Quiz, userID int, versionID *int) ([]models.Question, error) {
+	if versionID == nil {
+		if quiz.UserID != userID {
+			return nil, sql.ErrNoRows
+		}
+
+		return s.repo.GetQuestionsByQuizID(ctx, quiz.ID)
+	}
+
+	version, err := s.quizRepo.GetVersion(ctx, *versionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	questions := make([]models.Question, 0, len(version.Questions))
+
+	for _, question := range version.Questions {
+		questions = append(questions, models.Question{
+			ID:            question.ID,
+			Title:         question.Title,
+			Image:         question.Image,
+			Audio:         question.Audio,
+			QuizID:        question.QuizID,
+			Type:          question.Type,
+			OrderID:       question.OrderID,
+			Scoring:       question.Scoring,
+			Points:        question.Points,
+			Tag:           question.Tag,
+			MatchMode:     question.MatchMode,
+			IgnoreAccents: question.IgnoreAccents,
+			TypoTolerance: question.TypoTolerance,
+		})
+	}
+
 	return questions, nil
 }
 
@@ -88,7 +214,18 @@ func (s *Service) GetQuestionsAuthor(ctx context.Context, quizID, userID int) ([
 		return nil, http_errors.ErrPermissionDenied
 	}
 
-	return s.repo.GetQuestionsAuthor(ctx, quizID)
+	questions, err := s.repo.GetQuestionsAuthor(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	content.QuestionsWithAnswers(questions)
+
+	return questions, nil
 }
 
 func (s *Service) Update(ctx context.Context, id, userID, quizID int, input domain.Question) error {
@@ -104,6 +241,14 @@ func (s *Service) Update(ctx context.Context, id, userID, quizID int, input doma
 		return err
 	}
 
+	if err := types.ValidateScoring(input.Type, input.Scoring); err != nil {
+		return fmt.Errorf("%w: %v", http_errors.ErrWrongArgument, err)
+	}
+
+	if err := s.validateAnswers(ctx, id, quizID, input); err != nil {
+		return err
+	}
+
 	err = s.repo.Update(ctx, id, input)
 
 	if err != nil {
@@ -129,6 +274,15 @@ func (s *Service) Delete(ctx context.Context, id, userID, quizID int) error {
 		return err
 	}
 
+	fileNames, err := s.repo.GetMediaFiles(ctx, id)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
 	err = s.repo.Delete(ctx, id)
 
 	if err != nil {
@@ -138,6 +292,8 @@ func (s *Service) Delete(ctx context.Context, id, userID, quizID int) error {
 		return err
 	}
 
+	media.DeleteUnused(ctx, s.log, s.quizRepo, s.awsRepo, quizID, fileNames)
+
 	return nil
 }
 
@@ -176,14 +332,6 @@ func (s *Service) UploadImage(ctx context.Context, id, userID, quizID int, fileH
 		return err
 	}
 
-	if question.Image != "" {
-		err = s.awsRepo.DeleteFile(ctx, question.Image)
-
-		if err != nil {
-			return err
-		}
-	}
-
 	err = s.awsRepo.SaveFile(ctx, fileName, contentType, bytes)
 
 	if err != nil {
@@ -202,6 +350,8 @@ func (s *Service) UploadImage(ctx context.Context, id, userID, quizID int, fileH
 		return err
 	}
 
+	media.DeleteUnused(ctx, s.log, s.quizRepo, s.awsRepo, quizID, []string{question.Image})
+
 	return nil
 }
 
@@ -209,7 +359,16 @@ func (s *Service) DeleteImage(ctx context.Context, id, userID, quizID int) error
 	ctx, span := s.tracer.Start(ctx, "questionService.DeleteImage")
 	defer span.End()
 
-	err := s.checkPermissions(ctx, userID, quizID, id)
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	question, err := s.repo.GetQuestionByID(ctx, id)
 
 	if err != nil {
 		span.RecordError(err)
@@ -218,6 +377,10 @@ func (s *Service) DeleteImage(ctx context.Context, id, userID, quizID int) error
 		return err
 	}
 
+	if quiz.UserID != userID || question.QuizID != quizID {
+		return http_errors.ErrPermissionDenied
+	}
+
 	err = s.repo.DeleteImage(ctx, id)
 
 	if err != nil {
@@ -227,6 +390,106 @@ func (s *Service) DeleteImage(ctx context.Context, id, userID, quizID int) error
 		return err
 	}
 
+	media.DeleteUnused(ctx, s.log, s.quizRepo, s.awsRepo, quizID, []string{question.Image})
+
+	return nil
+}
+
+func (s *Service) UploadAudio(ctx context.Context, id, userID, quizID int, fileHeader *multipart.FileHeader) error {
+	ctx, span := s.tracer.Start(ctx, "questionService.UploadAudio")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	question, err := s.repo.GetQuestionByID(ctx, id)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if quiz.UserID != userID || question.QuizID != quizID {
+		return http_errors.ErrPermissionDenied
+	}
+
+	contentType, bytes, fileName, err := files.PrepareAudio(fileHeader)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	err = s.awsRepo.SaveFile(ctx, fileName, contentType, bytes)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	err = s.repo.UploadAudio(ctx, id, fileName)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	media.DeleteUnused(ctx, s.log, s.quizRepo, s.awsRepo, quizID, []string{question.Audio})
+
+	return nil
+}
+
+func (s *Service) DeleteAudio(ctx context.Context, id, userID, quizID int) error {
+	ctx, span := s.tracer.Start(ctx, "questionService.DeleteAudio")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	question, err := s.repo.GetQuestionByID(ctx, id)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if quiz.UserID != userID || question.QuizID != quizID {
+		return http_errors.ErrPermissionDenied
+	}
+
+	err = s.repo.DeleteAudio(ctx, id)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	media.DeleteUnused(ctx, s.log, s.quizRepo, s.awsRepo, quizID, []string{question.Audio})
+
 	return nil
 }
 
@@ -255,6 +518,40 @@ func (s *Service) ChangeOrder(ctx context.Context, userID, quizID int, input dom
 	return nil
 }
 
+// validateAnswers checks that the saved answers of the question still make sense for
+// its type and match mode, so a change can't turn them into answers that never grade.
+func (s *Service) validateAnswers(ctx context.Context, id, quizID int, input domain.Question) error {
+	questions, err := s.repo.GetQuestionsAuthor(ctx, quizID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, question := range questions {
+		if question.ID != id {
+			continue
+		}
+
+		matchMode := input.MatchMode
+
+		if matchMode == "" {
+			matchMode = question.MatchMode
+		}
+
+		answers := make([]domain.Answer, 0, len(question.Answers))
+
+		for _, answer := range question.Answers {
+			answers = append(answers, domain.Answer{Text: answer.Text, IsCorrect: answer.IsCorrect, MatchText: answer.MatchText})
+		}
+
+		if err := types.ValidateAnswers(input.Type, matchMode, answers); err != nil {
+			return fmt.Errorf("%w: %v", http_errors.ErrWrongArgument, err)
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) checkPermissions(ctx context.Context, userID, quizID, questionID int) error {
 	quiz, err := s.quizRepo.GetByID(ctx, quizID)
 
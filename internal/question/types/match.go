@@ -0,0 +1,92 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Match modes of input questions, see the match package for how each one compares.
+const (
+	MatchExact      = "exact"
+	MatchNormalized = "normalized"
+	MatchRegex      = "regex"
+	MatchNumeric    = "numeric"
+)
+
+const maxCachedPatterns = 1024
+
+var (
+	patternsMu sync.Mutex
+	patterns   = make(map[string]*regexp.Regexp)
+)
+
+// CompilePattern compiles an accepted regex answer into a case-insensitive pattern
+// for the whole text. Patterns are cached, since every graded answer needs them.
+func CompilePattern(text string) (*regexp.Regexp, error) {
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+
+	if re, ok := patterns[text]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile("(?i)^(?:" + strings.TrimSpace(text) + ")$")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(patterns) >= maxCachedPatterns {
+		clear(patterns)
+	}
+
+	patterns[text] = re
+
+	return re, nil
+}
+
+// ParseRational parses a decimal number or a fraction such as "1/2" or "-3/4".
+func ParseRational(text string) (float64, error) {
+	numerator, denominator, ok := strings.Cut(strings.TrimSpace(text), "/")
+
+	if !ok {
+		return ParseNumber(numerator)
+	}
+
+	n, err := ParseNumber(numerator)
+
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := ParseNumber(denominator)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if d == 0 {
+		return 0, errors.New("division by zero")
+	}
+
+	return n / d, nil
+}
+
+// validateMatch checks that an accepted answer of an input question can be used with the match mode.
+func validateMatch(mode, text string) error {
+	switch mode {
+	case MatchRegex:
+		if _, err := CompilePattern(text); err != nil {
+			return fmt.Errorf("invalid regular expression %q", text)
+		}
+	case MatchNumeric:
+		if _, err := ParseRational(text); err != nil {
+			return fmt.Errorf("invalid number %q", text)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/blazee5/quizmaster-backend/lib/random"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	Choice    = "choice"
+	Input     = "input"
+	Multiple  = "multiple"
+	TrueFalse = "true_false"
+	Ordering  = "ordering"
+	Matching  = "matching"
+	Numeric   = "numeric"
+	Essay     = "essay"
+)
+
+// numericEpsilon absorbs float rounding when a value sits exactly on a range bound.
+const numericEpsilon = 1e-9
+
+// HasOptions reports whether takers see the stored answers of the question.
+// Input and numeric questions keep them hidden because they are the accepted values,
+// essay answers are reference answers for the grader.
+func HasOptions(questionType string) bool {
+	return questionType != Input && questionType != Numeric && questionType != Essay
+}
+
+// IsChoice reports whether takers pick answers that are right or wrong on their own.
+func IsChoice(questionType string) bool {
+	return questionType == Choice || questionType == Multiple || questionType == TrueFalse
+}
+
+// ValidateScoring checks that the scoring rule makes sense for the question type.
+// Partial credit is only defined for multi-select, ordering and matching questions.
+func ValidateScoring(questionType, scoring string) error {
+	switch scoring {
+	case "", "all_or_nothing":
+		return nil
+	case "proportional":
+		if questionType == Multiple || questionType == Ordering || questionType == Matching {
+			return nil
+		}
+	case "penalty":
+		if questionType == Multiple {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s scoring is not supported for %s questions", scoring, questionType)
+}
+
+// ValidateAnswers checks each answer on its own against the question type and, for
+// input questions, the match mode. It is safe to run on drafts: answers without
+// text are skipped, since publishing rejects them.
+func ValidateAnswers(questionType, matchMode string, answers []domain.Answer) error {
+	for _, answer := range answers {
+		if answer.Text == "" {
+			continue
+		}
+
+		switch questionType {
+		case Numeric:
+			if _, _, err := ParseNumeric(answer.Text); err != nil {
+				return err
+			}
+		case Input:
+			if err := validateMatch(matchMode, answer.Text); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateKey checks that the answers form a gradable question of the given type.
+func ValidateKey(questionType, matchMode string, answers []domain.Answer) error {
+	if err := ValidateAnswers(questionType, matchMode, answers); err != nil {
+		return err
+	}
+
+	correct := 0
+
+	for _, answer := range answers {
+		if answer.IsCorrect {
+			correct++
+		}
+	}
+
+	switch questionType {
+	case Essay:
+		return nil
+	case Input:
+		if len(answers) == 0 {
+			return errors.New("needs at least one accepted answer")
+		}
+	case Numeric:
+		if len(answers) == 0 {
+			return errors.New("needs at least one accepted value")
+		}
+	case TrueFalse:
+		if len(answers) != 2 {
+			return errors.New("needs exactly two answers")
+		}
+
+		if correct != 1 {
+			return errors.New("needs exactly one correct answer")
+		}
+	case Ordering:
+		if len(answers) < 2 {
+			return errors.New("needs at least two answers")
+		}
+	case Matching:
+		if len(answers) < 2 {
+			return errors.New("needs at least two pairs")
+		}
+
+		for _, answer := range answers {
+			if strings.TrimSpace(answer.MatchText) == "" {
+				return errors.New("has an answer without a match")
+			}
+		}
+	default:
+		if len(answers) < 2 {
+			return errors.New("needs at least two answers")
+		}
+
+		if correct == 0 {
+			return errors.New("has no correct answer")
+		}
+	}
+
+	return nil
+}
+
+// ParseNumeric parses an accepted numeric answer into the range of values it allows.
+// Supported forms are an exact value ("9.81"), a value with a tolerance ("9.81±0.05"
+// or "9.81+-0.05") and an inclusive range ("9.7..9.9").
+func ParseNumeric(text string) (float64, float64, error) {
+	text = strings.TrimSpace(text)
+
+	if low, high, ok := strings.Cut(text, ".."); ok {
+		lo, err := ParseNumber(low)
+
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid numeric answer %q", text)
+		}
+
+		hi, err := ParseNumber(high)
+
+		if err != nil || hi < lo {
+			return 0, 0, fmt.Errorf("invalid numeric answer %q", text)
+		}
+
+		return lo, hi, nil
+	}
+
+	for _, separator := range []string{"±", "+-"} {
+		if value, tolerance, ok := strings.Cut(text, separator); ok {
+			x, err := ParseNumber(value)
+
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid numeric answer %q", text)
+			}
+
+			t, err := ParseNumber(tolerance)
+
+			if err != nil || t < 0 {
+				return 0, 0, fmt.Errorf("invalid numeric answer %q", text)
+			}
+
+			return x - t, x + t, nil
+		}
+	}
+
+	x, err := ParseNumber(text)
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid numeric answer %q", text)
+	}
+
+	return x, x, nil
+}
+
+// ParseNumber parses a number typed by a person. A single decimal comma is accepted
+// when there is no decimal point, but not when it reads like a thousands separator
+// ("1,000"), since guessing would silently grade the answer as 1.
+func ParseNumber(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+
+	if strings.Trim(text, "0123456789+-.eE,") != "" {
+		return 0, fmt.Errorf("invalid number %q", text)
+	}
+
+	if whole, fraction, ok := strings.Cut(text, ","); ok {
+		if strings.ContainsAny(fraction, ",.") || strings.Contains(whole, ".") {
+			return 0, fmt.Errorf("invalid number %q", text)
+		}
+
+		if len(fraction) == 3 && strings.TrimLeft(whole, "+-0") != "" {
+			return 0, fmt.Errorf("ambiguous number %q", text)
+		}
+
+		text = whole + "." + fraction
+	}
+
+	return strconv.ParseFloat(text, 64)
+}
+
+// NumericScore returns 1 if the text is a number inside any of the accepted ranges.
+func NumericScore(answers []models.Answer, text string) float64 {
+	value, err := ParseNumber(text)
+
+	if err != nil {
+		return 0
+	}
+
+	for _, answer := range answers {
+		lo, hi, err := ParseNumeric(answer.Text)
+
+		if err != nil {
+			continue
+		}
+
+		if value >= lo-numericEpsilon && value <= hi+numericEpsilon {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// HideKey removes what would give the answer away from the answers shown to a taker.
+// Ordering answers are shuffled, lose their position and get masked ids, matching
+// answers get their right-hand items shuffled between them. The seeds keep the
+// result stable per attempt.
+func HideKey(questionType string, answers []models.AnswerInfo, seeds ...int) {
+	switch questionType {
+	case Ordering:
+		ids := make([]int, 0, len(answers))
+
+		for _, answer := range answers {
+			ids = append(ids, answer.ID)
+		}
+
+		masks := MaskIDs(ids, seeds...)
+
+		random.Shuffle(answers, seeds...)
+
+		for i := range answers {
+			answers[i].ID = masks[answers[i].ID]
+			answers[i].OrderID = 0
+		}
+	case Matching:
+		matches := make([]string, 0, len(answers))
+
+		for _, answer := range answers {
+			matches = append(matches, answer.MatchText)
+		}
+
+		random.Shuffle(matches, seeds...)
+
+		for i := range answers {
+			answers[i].MatchText = matches[i]
+		}
+	}
+}
+
+// MaskIDs maps the answer ids of an ordering question to a keyed permutation of
+// the same ids. Serial ids follow the order the author typed the answers in,
+// which is usually the right one.
+func MaskIDs(ids []int, seeds ...int) map[int]int {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+
+	masked := slices.Clone(sorted)
+	random.Shuffle(masked, append(slices.Clone(seeds), -1)...)
+
+	masks := make(map[int]int, len(sorted))
+
+	for i, id := range sorted {
+		masks[id] = masked[i]
+	}
+
+	return masks
+}
+
+// UnmaskIDs maps ids sent back by a taker to the answer ids. Unknown ids are kept
+// as they are, so validation still rejects them.
+func UnmaskIDs(answers []models.Answer, masked []int, seeds ...int) []int {
+	ids := make([]int, 0, len(answers))
+
+	for _, answer := range answers {
+		ids = append(ids, answer.ID)
+	}
+
+	unmask := make(map[int]int, len(ids))
+
+	for id, mask := range MaskIDs(ids, seeds...) {
+		unmask[mask] = id
+	}
+
+	result := make([]int, 0, len(masked))
+
+	for _, mask := range masked {
+		if id, ok := unmask[mask]; ok {
+			result = append(result, id)
+		} else {
+			result = append(result, mask)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHideKeyMasksOrderingIDs(t *testing.T) {
+	t.Parallel()
+
+	answers := []models.Answer{{ID: 10}, {ID: 11}, {ID: 12}, {ID: 13}, {ID: 14}}
+	shown := []models.AnswerInfo{{ID: 10, OrderID: 1}, {ID: 11, OrderID: 2}, {ID: 12, OrderID: 3}, {ID: 13, OrderID: 4}, {ID: 14, OrderID: 5}}
+
+	HideKey(Ordering, shown, 7, 3)
+
+	masked := make([]int, 0, len(shown))
+
+	for _, answer := range shown {
+		require.Zero(t, answer.OrderID)
+		masked = append(masked, answer.ID)
+	}
+
+	require.ElementsMatch(t, []int{10, 11, 12, 13, 14}, masked)
+
+	masks := MaskIDs([]int{14, 13, 12, 11, 10}, 7, 3)
+	sent := []int{masks[10], masks[11], masks[12], masks[13], masks[14]}
+
+	require.Equal(t, []int{10, 11, 12, 13, 14}, UnmaskIDs(answers, sent, 7, 3))
+	require.Equal(t, []int{99}, UnmaskIDs(answers, []int{99}, 7, 3))
+}
+
+func TestParseNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text     string
+		expected float64
+		valid    bool
+	}{
+		{"9.81", 9.81, true},
+		{" 9,81 ", 9.81, true},
+		{"-0,5", -0.5, true},
+		{"0,125", 0.125, true},
+		{"1e3", 1000, true},
+		{"1,000", 0, false},
+		{"1,000.5", 0, false},
+		{"1.000,5", 0, false},
+		{"1,2,3", 0, false},
+		{"NaN", 0, false},
+		{"Inf", 0, false},
+		{"0x10", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := ParseNumber(tt.text)
+
+			if !tt.valid {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.InDelta(t, tt.expected, value, 1e-9)
+		})
+	}
+}
+
+func TestValidateAnswers(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateAnswers(Numeric, "", []domain.Answer{{Text: "9,81"}, {Text: ""}}))
+	require.Error(t, ValidateAnswers(Numeric, "", []domain.Answer{{Text: "1,000"}}))
+	require.Error(t, ValidateAnswers(Numeric, "", []domain.Answer{{Text: "Paris"}}))
+	require.NoError(t, ValidateAnswers(Choice, "", []domain.Answer{{Text: "Paris"}}))
+
+	require.NoError(t, ValidateAnswers(Input, MatchRegex, []domain.Answer{{Text: `\d+`}}))
+	require.Error(t, ValidateAnswers(Input, MatchRegex, []domain.Answer{{Text: `(`}}))
+	require.NoError(t, ValidateAnswers(Input, MatchNumeric, []domain.Answer{{Text: "-3/4"}}))
+	require.Error(t, ValidateAnswers(Input, MatchNumeric, []domain.Answer{{Text: "1/0"}}))
+	require.NoError(t, ValidateAnswers(Input, MatchExact, []domain.Answer{{Text: "("}}))
+
+	require.Error(t, ValidateKey(Choice, "", []domain.Answer{{Text: "Paris", IsCorrect: true}}))
+	require.Error(t, ValidateKey(Numeric, "", []domain.Answer{{Text: "Paris"}}))
+	require.Error(t, ValidateKey(Input, MatchRegex, []domain.Answer{{Text: `[a-`}}))
+}
+
+func TestCompilePatternCaches(t *testing.T) {
+	t.Parallel()
+
+	re, err := CompilePattern(" colou?r ")
+	require.NoError(t, err)
+	require.True(t, re.MatchString("Color"))
+	require.False(t, re.MatchString("colors"))
+
+	cached, err := CompilePattern(" colou?r ")
+	require.NoError(t, err)
+	require.Same(t, re, cached)
+}
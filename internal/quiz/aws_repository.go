@@ -5,4 +5,5 @@ import "context"
 type AWSRepository interface {
 	SaveFile(ctx context.Context, fileName, contentType string, chunk []byte) error
 	DeleteFile(ctx context.Context, fileName string) error
+	CopyFile(ctx context.Context, srcFileName, dstFileName string) error
 }
@@ -0,0 +1,79 @@
+package format
+
+import (
+	"fmt"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"io"
+)
+
+const (
+	JSON = "json"
+	GIFT = "gift"
+	QTI  = "qti"
+)
+
+func Encode(w io.Writer, format string, tree domain.QuizTree) error {
+	switch format {
+	case JSON:
+		return encodeJSON(w, tree)
+	case GIFT:
+		return encodeGIFT(w, tree)
+	case QTI:
+		return encodeQTI(w, tree)
+	}
+
+	return fmt.Errorf("%w: unknown format %q", http_errors.ErrInvalidFormat, format)
+}
+
+func Decode(r io.Reader, format string) (domain.QuizTree, error) {
+	var tree domain.QuizTree
+	var err error
+
+	switch format {
+	case JSON:
+		tree, err = decodeJSON(r)
+	case GIFT:
+		tree, err = decodeGIFT(r)
+	case QTI:
+		tree, err = decodeQTI(r)
+	default:
+		return domain.QuizTree{}, fmt.Errorf("%w: unknown format %q", http_errors.ErrInvalidFormat, format)
+	}
+
+	if err != nil {
+		return domain.QuizTree{}, fmt.Errorf("%w: %s", http_errors.ErrInvalidFormat, err)
+	}
+
+	for i := range tree.Questions {
+		tree.Questions[i].OrderID = i
+
+		for j := range tree.Questions[i].Answers {
+			tree.Questions[i].Answers[j].OrderID = j
+		}
+	}
+
+	return tree, nil
+}
+
+func ContentType(format string) string {
+	switch format {
+	case GIFT:
+		return "text/plain; charset=utf-8"
+	case QTI:
+		return "application/zip"
+	}
+
+	return "application/json"
+}
+
+func Extension(format string) string {
+	switch format {
+	case GIFT:
+		return "gift.txt"
+	case QTI:
+		return "qti.zip"
+	}
+
+	return "json"
+}
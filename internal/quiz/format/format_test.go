@@ -0,0 +1,275 @@
+package format
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func answers(items ...domain.Answer) []domain.AnswerTree {
+	tree := make([]domain.AnswerTree, 0, len(items))
+
+	for _, item := range items {
+		tree = append(tree, domain.AnswerTree{Answer: item})
+	}
+
+	return tree
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func sampleTree() domain.QuizTree {
+	tree := domain.QuizTree{
+		Quiz: domain.Quiz{
+			Title:       "Geography: capitals",
+			Description: "Европа и Азия",
+			TimeLimit:   600,
+			ScorePolicy: "best",
+		},
+		Image: "cover.png",
+	}
+
+	choice := domain.QuestionTree{Image: "map.png"}
+	choice.Title = "Capital of France {2024}?"
+	choice.Type = "choice"
+	choice.Points = intPtr(2)
+	choice.Tag = "europe"
+	choice.Answers = answers(domain.Answer{Text: "Paris", IsCorrect: true}, domain.Answer{Text: "Lyon"}, domain.Answer{Text: "a = b ~ c"})
+
+	multiple := domain.QuestionTree{}
+	multiple.Title = "Cities in Japan"
+	multiple.Type = "multiple"
+	multiple.Scoring = "penalty"
+	multiple.Points = intPtr(3)
+	multiple.Tag = "asia"
+	multiple.Answers = answers(domain.Answer{Text: "Tokyo", IsCorrect: true}, domain.Answer{Text: "Seoul"}, domain.Answer{Text: "Osaka", IsCorrect: true})
+
+	input := domain.QuestionTree{}
+	input.Title = "Capital of Italy"
+	input.Type = "input"
+	input.Points = intPtr(0)
+	input.Tag = "europe"
+	input.Answers = answers(domain.Answer{Text: "Rome", IsCorrect: true}, domain.Answer{Text: "Roma", IsCorrect: true})
+
+	tree.Questions = []domain.QuestionTree{choice, multiple, input}
+
+	for i := range tree.Questions {
+		tree.Questions[i].OrderID = i
+
+		for j := range tree.Questions[i].Answers {
+			tree.Questions[i].Answers[j].OrderID = j
+		}
+	}
+
+	return tree
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{JSON, GIFT, QTI} {
+		format := format
+
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+
+			expected := sampleTree()
+
+			var buf bytes.Buffer
+
+			require.NoError(t, Encode(&buf, format, expected))
+
+			actual, err := Decode(&buf, format)
+			require.NoError(t, err)
+
+			require.Equal(t, expected.Title, actual.Title)
+			require.Equal(t, expected.Description, actual.Description)
+			require.Len(t, actual.Questions, len(expected.Questions))
+
+			for i, question := range expected.Questions {
+				require.Equal(t, question.Title, actual.Questions[i].Title)
+				require.Equal(t, question.Type, actual.Questions[i].Type)
+				require.Equal(t, question.Scoring, actual.Questions[i].Scoring)
+				require.Equal(t, question.Answers, actual.Questions[i].Answers)
+
+				switch format {
+				case JSON:
+					require.Equal(t, question, actual.Questions[i])
+				case GIFT:
+					require.Equal(t, question.Tag, actual.Questions[i].Tag)
+				case QTI:
+					require.Equal(t, question.Image, actual.Questions[i].Image)
+					require.Equal(t, question.Points, actual.Questions[i].Points)
+				}
+			}
+
+			if format == JSON {
+				require.Equal(t, expected, actual)
+			}
+		})
+	}
+}
+
+func TestDecodeGIFT(t *testing.T) {
+	t.Parallel()
+
+	input := `// Moodle export
+
+$CATEGORY: $course$/top/Rivers
+
+::Q1:: Longest river in Africa? {
+	=Nile#Correct!
+	~Congo#No
+}
+
+Grant is buried in Grant's tomb. {T}
+
+::Q3:: Name a primary colour {=red =blue =yellow}
+
+Pick the even numbers {
+	~%50%2
+	~%50%4
+	~%-100%3
+}
+
+This text has no answers and is skipped.
+`
+
+	tree, err := Decode(strings.NewReader(input), GIFT)
+	require.NoError(t, err)
+
+	require.Equal(t, "Moodle export", tree.Title)
+	require.Len(t, tree.Questions, 4)
+
+	require.Equal(t, "Longest river in Africa?", tree.Questions[0].Title)
+	require.Equal(t, "choice", tree.Questions[0].Type)
+	require.Equal(t, "Rivers", tree.Questions[0].Tag)
+	require.Equal(t, answers(domain.Answer{Text: "Nile", IsCorrect: true}, domain.Answer{Text: "Congo", OrderID: 1}), tree.Questions[0].Answers)
+
+	require.Equal(t, "true_false", tree.Questions[1].Type)
+	require.True(t, tree.Questions[1].Answers[0].IsCorrect)
+
+	require.Equal(t, "input", tree.Questions[2].Type)
+	require.Len(t, tree.Questions[2].Answers, 3)
+
+	require.Equal(t, "multiple", tree.Questions[3].Type)
+	require.Equal(t, "penalty", tree.Questions[3].Scoring)
+	require.False(t, tree.Questions[3].Answers[2].IsCorrect)
+
+	tree, err = Decode(strings.NewReader("Solve {#2:0.1}\n\nRange {#\n\t=1..5\n\t=%0%7\n}"), GIFT)
+	require.NoError(t, err)
+	require.Equal(t, "numeric", tree.Questions[0].Type)
+	require.Equal(t, "2±0.1", tree.Questions[0].Answers[0].Text)
+	require.Len(t, tree.Questions[1].Answers, 1)
+	require.Equal(t, "1..5", tree.Questions[1].Answers[0].Text)
+
+	tree, err = Decode(strings.NewReader("Describe yourself {}"), GIFT)
+	require.NoError(t, err)
+	require.Equal(t, "essay", tree.Questions[0].Type)
+	require.Empty(t, tree.Questions[0].Answers)
+}
+
+func TestGIFTQuestionTypes(t *testing.T) {
+	t.Parallel()
+
+	trueFalse := domain.QuestionTree{}
+	trueFalse.Title = "The Earth is flat"
+	trueFalse.Type = "true_false"
+	trueFalse.Explanation = "It is an oblate spheroid"
+	trueFalse.Answers = answers(domain.Answer{Text: "True"}, domain.Answer{Text: "False", IsCorrect: true, OrderID: 1})
+
+	matching := domain.QuestionTree{}
+	matching.Title = "Match the formulas"
+	matching.Type = "matching"
+	matching.Answers = answers(domain.Answer{Text: "H2O", MatchText: "water"}, domain.Answer{Text: "NaCl", MatchText: "salt", OrderID: 1})
+
+	numeric := domain.QuestionTree{}
+	numeric.Title = "Gravity on Earth"
+	numeric.Type = "numeric"
+	numeric.Explanation = "Standard gravity is 9.80665 m/s²"
+	numeric.Answers = answers(domain.Answer{Text: "9.81±0.05", IsCorrect: true}, domain.Answer{Text: "-1..1", IsCorrect: true, OrderID: 1})
+
+	essay := domain.QuestionTree{}
+	essay.Title = "Describe the water cycle"
+	essay.Type = "essay"
+	essay.Explanation = "Evaporation, condensation and precipitation: $H_2O$ {cycles}"
+
+	expected := domain.QuizTree{Questions: []domain.QuestionTree{trueFalse, matching, numeric, essay}}
+	expected.Title = "Science"
+
+	for i := range expected.Questions {
+		expected.Questions[i].OrderID = i
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, Encode(&buf, GIFT, expected))
+
+	actual, err := Decode(&buf, GIFT)
+	require.NoError(t, err)
+	require.Equal(t, expected.Questions, actual.Questions)
+
+	ordering := domain.QuestionTree{}
+	ordering.Title = "Sort the planets"
+	ordering.Type = "ordering"
+	ordering.Answers = answers(domain.Answer{Text: "Mercury"}, domain.Answer{Text: "Venus", OrderID: 1})
+
+	for _, format := range []string{GIFT, QTI} {
+		err := Encode(&bytes.Buffer{}, format, domain.QuizTree{Questions: []domain.QuestionTree{ordering}})
+		require.ErrorIs(t, err, http_errors.ErrInvalidFormat)
+	}
+}
+
+func TestDecodeQTILimits(t *testing.T) {
+	t.Parallel()
+
+	archive := func(files map[string]string) *bytes.Buffer {
+		var buf bytes.Buffer
+
+		writer := zip.NewWriter(&buf)
+
+		for name, content := range files {
+			f, err := writer.Create(name)
+			require.NoError(t, err)
+
+			_, err = f.Write([]byte(content))
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, writer.Close())
+
+		return &buf
+	}
+
+	tooMany := make(map[string]string, qtiMaxFiles+1)
+
+	for i := 0; i <= qtiMaxFiles; i++ {
+		tooMany[fmt.Sprintf("item%d.xml", i)] = ""
+	}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{"too many files", tooMany},
+		{"oversized file", map[string]string{qtiManifestPath: "<manifest>" + strings.Repeat(" ", qtiMaxFileBytes) + "</manifest>"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Decode(archive(tt.files), QTI)
+			require.ErrorIs(t, err, http_errors.ErrInvalidFormat)
+		})
+	}
+}
@@ -0,0 +1,489 @@
+package format
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var giftNumericReplacer = strings.NewReplacer("±", ":", "+-", ":")
+
+var giftEscaper = strings.NewReplacer(`\`, `\\`, "~", `\~`, "=", `\=`, "#", `\#`, "{", `\{`, "}", `\}`, ":", `\:`, "\n", `\n`)
+
+type giftToken struct {
+	kind byte
+	text string
+}
+
+func encodeGIFT(w io.Writer, tree domain.QuizTree) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "// %s\n", strings.ReplaceAll(tree.Title, "\n", " "))
+
+	if tree.Description != "" {
+		for _, line := range strings.Split(tree.Description, "\n") {
+			fmt.Fprintf(bw, "// %s\n", line)
+		}
+	}
+
+	bw.WriteString("\n")
+
+	tag := ""
+
+	for i, question := range tree.Questions {
+		if question.Tag != tag {
+			fmt.Fprintf(bw, "$CATEGORY: %s\n\n", question.Tag)
+			tag = question.Tag
+		}
+
+		feedback := ""
+
+		if question.Explanation != "" {
+			feedback = "####" + giftEscaper.Replace(question.Explanation)
+		}
+
+		switch question.Type {
+		case types.Ordering:
+			return fmt.Errorf("%w: question %d: ordering questions are not supported by GIFT", http_errors.ErrInvalidFormat, i+1)
+		case types.TrueFalse:
+			value := "F"
+
+			if len(question.Answers) > 0 && question.Answers[0].IsCorrect {
+				value = "T"
+			}
+
+			fmt.Fprintf(bw, "::Q%d:: %s {%s%s}\n\n", i+1, giftEscaper.Replace(question.Title), value, feedback)
+
+			continue
+		case types.Essay:
+			fmt.Fprintf(bw, "::Q%d:: %s {%s}\n\n", i+1, giftEscaper.Replace(question.Title), feedback)
+
+			continue
+		}
+
+		fmt.Fprintf(bw, "::Q%d:: %s {", i+1, giftEscaper.Replace(question.Title))
+
+		if question.Type == types.Numeric {
+			bw.WriteString("#")
+		}
+
+		bw.WriteString("\n")
+
+		correct, wrong := 0, 0
+
+		for _, answer := range question.Answers {
+			if answer.IsCorrect {
+				correct++
+			} else {
+				wrong++
+			}
+		}
+
+		for _, answer := range question.Answers {
+			var prefix string
+
+			switch question.Type {
+			case types.Numeric:
+				fmt.Fprintf(bw, "\t=%s\n", giftNumericReplacer.Replace(strings.TrimSpace(answer.Text)))
+
+				continue
+			case types.Matching:
+				fmt.Fprintf(bw, "\t=%s -> %s\n", giftEscaper.Replace(answer.Text), giftEscaper.Replace(answer.MatchText))
+
+				continue
+			}
+
+			switch {
+			case question.Type == types.Multiple && answer.IsCorrect:
+				prefix = "~%" + giftWeight(100/float64(correct)) + "%"
+			case question.Type == types.Multiple && question.Scoring == "penalty":
+				prefix = "~%" + giftWeight(-100/float64(wrong)) + "%"
+			case question.Type == types.Multiple:
+				prefix = "~%0%"
+			case answer.IsCorrect:
+				prefix = "="
+			default:
+				prefix = "~"
+			}
+
+			fmt.Fprintf(bw, "\t%s%s\n", prefix, giftEscaper.Replace(answer.Text))
+		}
+
+		if feedback != "" {
+			fmt.Fprintf(bw, "\t%s\n", feedback)
+		}
+
+		bw.WriteString("}\n\n")
+	}
+
+	return bw.Flush()
+}
+
+func giftWeight(weight float64) string {
+	return strconv.FormatFloat(math.Round(weight*1e5)/1e5, 'f', -1, 64)
+}
+
+func decodeGIFT(r io.Reader) (domain.QuizTree, error) {
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return domain.QuizTree{}, err
+	}
+
+	var tree domain.QuizTree
+	var comments []string
+	var block []string
+
+	tag := ""
+
+	flush := func() error {
+		if len(block) == 0 {
+			return nil
+		}
+
+		question, ok, err := parseGIFTQuestion(strings.Join(block, "\n"))
+		block = nil
+
+		if err != nil || !ok {
+			return err
+		}
+
+		question.Tag = tag
+		tree.Questions = append(tree.Questions, question)
+
+		return nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "//"):
+			if len(tree.Questions) == 0 && len(block) == 0 {
+				comments = append(comments, strings.TrimSpace(trimmed[2:]))
+			}
+		case strings.HasPrefix(trimmed, "$CATEGORY:"):
+			if err := flush(); err != nil {
+				return domain.QuizTree{}, err
+			}
+
+			category := strings.TrimSpace(strings.TrimPrefix(trimmed, "$CATEGORY:"))
+			tag = category[strings.LastIndex(category, "/")+1:]
+		case trimmed == "":
+			if err := flush(); err != nil {
+				return domain.QuizTree{}, err
+			}
+		default:
+			block = append(block, line)
+		}
+	}
+
+	if err := flush(); err != nil {
+		return domain.QuizTree{}, err
+	}
+
+	if len(comments) > 0 {
+		tree.Title = comments[0]
+		tree.Description = strings.Join(comments[1:], "\n")
+	}
+
+	return tree, nil
+}
+
+func parseGIFTQuestion(text string) (domain.QuestionTree, bool, error) {
+	text = strings.TrimSpace(text)
+	name := ""
+
+	if strings.HasPrefix(text, "::") {
+		end := strings.Index(text[2:], "::")
+
+		if end < 0 {
+			return domain.QuestionTree{}, false, errors.New("unclosed question name")
+		}
+
+		name = giftUnescape(strings.TrimSpace(text[2 : end+2]))
+		text = strings.TrimSpace(text[end+4:])
+	}
+
+	for _, marker := range []string{"[html]", "[moodle]", "[plain]", "[markdown]"} {
+		text = strings.TrimPrefix(text, marker)
+	}
+
+	open := giftIndex(text, 0, '{')
+
+	if open < 0 {
+		return domain.QuestionTree{}, false, nil
+	}
+
+	closing := giftIndex(text, open, '}')
+
+	if closing < 0 {
+		return domain.QuestionTree{}, false, fmt.Errorf("unclosed answer block in question %q", name)
+	}
+
+	question := domain.QuestionTree{}
+	question.Title = giftUnescape(strings.TrimSpace(strings.TrimSpace(text[:open]) + " " + strings.TrimSpace(text[closing+1:])))
+
+	if question.Title == "" {
+		question.Title = name
+	}
+
+	body := text[open+1 : closing]
+
+	// General feedback after #### becomes the explanation of the question.
+	if i := giftFeedbackIndex(body); i >= 0 {
+		question.Explanation = giftUnescape(strings.TrimSpace(body[i+4:]))
+		body = body[:i]
+	}
+
+	body = strings.TrimSpace(body)
+
+	switch strings.ToUpper(body) {
+	case "T", "TRUE", "F", "FALSE":
+		isTrue := strings.HasPrefix(strings.ToUpper(body), "T")
+		question.Type = types.TrueFalse
+		question.Answers = []domain.AnswerTree{
+			{Answer: domain.Answer{Text: "True", IsCorrect: isTrue}},
+			{Answer: domain.Answer{Text: "False", IsCorrect: !isTrue}},
+		}
+
+		return question, true, nil
+	case "":
+		question.Type = types.Essay
+
+		return question, true, nil
+	}
+
+	if strings.HasPrefix(body, "#") {
+		return parseGIFTNumeric(question, body[1:])
+	}
+
+	tokens := splitGIFTAnswers(body)
+
+	if len(tokens) > 0 && strings.Contains(tokens[0].text, "->") {
+		return parseGIFTMatching(question, tokens)
+	}
+	weighted, hasWrong := false, false
+
+	for _, token := range tokens {
+		if strings.HasPrefix(token.text, "%") {
+			weighted = true
+		}
+
+		if token.kind == '~' {
+			hasWrong = true
+		}
+	}
+
+	switch {
+	case weighted:
+		question.Type = types.Multiple
+	case hasWrong:
+		question.Type = types.Choice
+	default:
+		question.Type = types.Input
+	}
+
+	for _, token := range tokens {
+		text := token.text
+
+		if strings.Contains(text, "->") {
+			return domain.QuestionTree{}, false, fmt.Errorf("question %q mixes matching and choice answers", question.Title)
+		}
+
+		if feedback := giftIndex(text, 0, '#'); feedback >= 0 {
+			text = text[:feedback]
+		}
+
+		text = strings.TrimSpace(text)
+		isCorrect := token.kind == '='
+
+		if strings.HasPrefix(text, "%") {
+			end := strings.Index(text[1:], "%")
+
+			if end < 0 {
+				return domain.QuestionTree{}, false, fmt.Errorf("invalid answer weight in question %q", question.Title)
+			}
+
+			weight, err := strconv.ParseFloat(text[1:end+1], 64)
+
+			if err != nil {
+				return domain.QuestionTree{}, false, fmt.Errorf("invalid answer weight in question %q", question.Title)
+			}
+
+			if weight < 0 {
+				question.Scoring = "penalty"
+			}
+
+			isCorrect = weight > 0
+			text = strings.TrimSpace(text[end+2:])
+		}
+
+		question.Answers = append(question.Answers, domain.AnswerTree{Answer: domain.Answer{
+			Text:      giftUnescape(text),
+			IsCorrect: isCorrect,
+		}})
+	}
+
+	return question, true, nil
+}
+
+// parseGIFTNumeric reads "{#value:tolerance}", "{#min..max}" and the multi-answer
+// "{# =value:tolerance =min..max}" forms. Answers with a zero weight are dropped.
+func parseGIFTNumeric(question domain.QuestionTree, body string) (domain.QuestionTree, bool, error) {
+	question.Type = types.Numeric
+
+	tokens := splitGIFTAnswers(body)
+
+	if len(tokens) == 0 {
+		tokens = []giftToken{{kind: '=', text: body}}
+	}
+
+	for _, token := range tokens {
+		text := token.text
+
+		if feedback := giftIndex(text, 0, '#'); feedback >= 0 {
+			text = text[:feedback]
+		}
+
+		text = strings.TrimSpace(text)
+
+		if strings.HasPrefix(text, "%") {
+			end := strings.Index(text[1:], "%")
+
+			if end < 0 {
+				return domain.QuestionTree{}, false, fmt.Errorf("invalid answer weight in question %q", question.Title)
+			}
+
+			if weight, err := strconv.ParseFloat(text[1:end+1], 64); err != nil || weight <= 0 {
+				continue
+			}
+
+			text = strings.TrimSpace(text[end+2:])
+		}
+
+		if token.kind == '~' || text == "" {
+			continue
+		}
+
+		if value, tolerance, ok := strings.Cut(text, ":"); ok {
+			text = strings.TrimSpace(value) + "±" + strings.TrimSpace(tolerance)
+		}
+
+		if _, _, err := types.ParseNumeric(text); err != nil {
+			return domain.QuestionTree{}, false, fmt.Errorf("question %q: %v", question.Title, err)
+		}
+
+		question.Answers = append(question.Answers, domain.AnswerTree{Answer: domain.Answer{Text: text, IsCorrect: true}})
+	}
+
+	if len(question.Answers) == 0 {
+		return domain.QuestionTree{}, false, fmt.Errorf("numeric question %q has no accepted value", question.Title)
+	}
+
+	return question, true, nil
+}
+
+func parseGIFTMatching(question domain.QuestionTree, tokens []giftToken) (domain.QuestionTree, bool, error) {
+	question.Type = types.Matching
+
+	for _, token := range tokens {
+		left, right, ok := strings.Cut(token.text, "->")
+		left, right = strings.TrimSpace(left), strings.TrimSpace(right)
+
+		if !ok || left == "" || right == "" {
+			return domain.QuestionTree{}, false, fmt.Errorf("matching question %q has an unpaired item", question.Title)
+		}
+
+		question.Answers = append(question.Answers, domain.AnswerTree{Answer: domain.Answer{
+			Text:      giftUnescape(left),
+			MatchText: giftUnescape(right),
+		}})
+	}
+
+	return question, true, nil
+}
+
+func splitGIFTAnswers(body string) []giftToken {
+	tokens := make([]giftToken, 0)
+	start := -1
+
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			i++
+		case '=', '~':
+			if start >= 0 {
+				tokens[len(tokens)-1].text = body[start:i]
+			}
+
+			tokens = append(tokens, giftToken{kind: body[i]})
+			start = i + 1
+		}
+	}
+
+	if start >= 0 {
+		tokens[len(tokens)-1].text = body[start:]
+	}
+
+	return tokens
+}
+
+func giftIndex(text string, from int, c byte) int {
+	for i := from; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+			continue
+		}
+
+		if text[i] == c {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func giftFeedbackIndex(text string) int {
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+			continue
+		}
+
+		if strings.HasPrefix(text[i:], "####") {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func giftUnescape(text string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) {
+			i++
+
+			if text[i] == 'n' {
+				sb.WriteByte('\n')
+			} else {
+				sb.WriteByte(text[i])
+			}
+
+			continue
+		}
+
+		sb.WriteByte(text[i])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,35 @@
+package format
+
+import (
+	"encoding/json"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"io"
+	"sort"
+)
+
+func encodeJSON(w io.Writer, tree domain.QuizTree) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(tree)
+}
+
+func decodeJSON(r io.Reader) (domain.QuizTree, error) {
+	var tree domain.QuizTree
+
+	if err := json.NewDecoder(r).Decode(&tree); err != nil {
+		return domain.QuizTree{}, err
+	}
+
+	sort.SliceStable(tree.Questions, func(i, j int) bool {
+		return tree.Questions[i].OrderID < tree.Questions[j].OrderID
+	})
+
+	for _, question := range tree.Questions {
+		sort.SliceStable(question.Answers, func(i, j int) bool {
+			return question.Answers[i].OrderID < question.Answers[j].OrderID
+		})
+	}
+
+	return tree, nil
+}
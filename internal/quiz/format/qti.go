@@ -0,0 +1,654 @@
+package format
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	qtiNamespace      = "http://www.imsglobal.org/xsd/imsqti_v2p1"
+	qtiCPNamespace    = "http://www.imsglobal.org/xsd/imscp_v1p1"
+	qtiTestType       = "imsqti_test_xmlv2p1"
+	qtiItemType       = "imsqti_item_xmlv2p1"
+	qtiMatchCorrect   = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/match_correct"
+	qtiMapResponse    = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/map_response"
+	qtiManifestPath   = "imsmanifest.xml"
+	qtiTestPath       = "test.xml"
+	qtiResponseID     = "RESPONSE"
+	qtiMaxImportBytes = 32 << 20
+	qtiMaxFileBytes   = 8 << 20
+	qtiMaxFiles       = 1000
+)
+
+type qtiManifest struct {
+	XMLName    xml.Name      `xml:"manifest"`
+	Xmlns      string        `xml:"xmlns,attr,omitempty"`
+	Identifier string        `xml:"identifier,attr"`
+	Resources  []qtiResource `xml:"resources>resource"`
+}
+
+type qtiResource struct {
+	Identifier   string          `xml:"identifier,attr"`
+	Type         string          `xml:"type,attr"`
+	Href         string          `xml:"href,attr"`
+	Files        []qtiFile       `xml:"file"`
+	Dependencies []qtiDependency `xml:"dependency"`
+}
+
+type qtiFile struct {
+	Href string `xml:"href,attr"`
+}
+
+type qtiDependency struct {
+	IdentifierRef string `xml:"identifierref,attr"`
+}
+
+type qtiTest struct {
+	XMLName    xml.Name      `xml:"assessmentTest"`
+	Xmlns      string        `xml:"xmlns,attr,omitempty"`
+	Identifier string        `xml:"identifier,attr"`
+	Title      string        `xml:"title,attr"`
+	TestParts  []qtiTestPart `xml:"testPart"`
+}
+
+type qtiTestPart struct {
+	Identifier     string       `xml:"identifier,attr"`
+	NavigationMode string       `xml:"navigationMode,attr"`
+	SubmissionMode string       `xml:"submissionMode,attr"`
+	Sections       []qtiSection `xml:"assessmentSection"`
+}
+
+type qtiSection struct {
+	Identifier string       `xml:"identifier,attr"`
+	Title      string       `xml:"title,attr"`
+	Visible    bool         `xml:"visible,attr"`
+	Rubric     *qtiRubric   `xml:"rubricBlock,omitempty"`
+	ItemRefs   []qtiItemRef `xml:"assessmentItemRef"`
+}
+
+type qtiRubric struct {
+	View string `xml:"view,attr"`
+	Text string `xml:"p"`
+}
+
+type qtiItemRef struct {
+	Identifier string `xml:"identifier,attr"`
+	Href       string `xml:"href,attr"`
+}
+
+type qtiItem struct {
+	XMLName            xml.Name               `xml:"assessmentItem"`
+	Xmlns              string                 `xml:"xmlns,attr,omitempty"`
+	Identifier         string                 `xml:"identifier,attr"`
+	Title              string                 `xml:"title,attr"`
+	Adaptive           bool                   `xml:"adaptive,attr"`
+	TimeDependent      bool                   `xml:"timeDependent,attr"`
+	Response           qtiResponseDeclaration `xml:"responseDeclaration"`
+	Outcome            qtiOutcomeDeclaration  `xml:"outcomeDeclaration"`
+	Body               qtiItemBody            `xml:"itemBody"`
+	ResponseProcessing qtiResponseProcessing  `xml:"responseProcessing"`
+}
+
+type qtiResponseDeclaration struct {
+	Identifier      string      `xml:"identifier,attr"`
+	Cardinality     string      `xml:"cardinality,attr"`
+	BaseType        string      `xml:"baseType,attr"`
+	CorrectResponse []string    `xml:"correctResponse>value"`
+	Mapping         *qtiMapping `xml:"mapping,omitempty"`
+}
+
+type qtiMapping struct {
+	DefaultValue float64       `xml:"defaultValue,attr"`
+	Entries      []qtiMapEntry `xml:"mapEntry"`
+}
+
+type qtiMapEntry struct {
+	MapKey        string  `xml:"mapKey,attr"`
+	MappedValue   float64 `xml:"mappedValue,attr"`
+	CaseSensitive *bool   `xml:"caseSensitive,attr,omitempty"`
+}
+
+type qtiOutcomeDeclaration struct {
+	Identifier    string `xml:"identifier,attr"`
+	Cardinality   string `xml:"cardinality,attr"`
+	BaseType      string `xml:"baseType,attr"`
+	NormalMaximum string `xml:"normalMaximum,attr,omitempty"`
+	DefaultValue  string `xml:"defaultValue>value"`
+}
+
+type qtiResponseProcessing struct {
+	Template string `xml:"template,attr,omitempty"`
+}
+
+type qtiItemBody struct {
+	Prompt    string
+	Image     string
+	Choice    *qtiChoiceInteraction
+	TextEntry bool
+}
+
+type qtiChoiceInteraction struct {
+	ResponseIdentifier string      `xml:"responseIdentifier,attr"`
+	Shuffle            bool        `xml:"shuffle,attr"`
+	MaxChoices         int         `xml:"maxChoices,attr"`
+	Choices            []qtiChoice `xml:"simpleChoice"`
+}
+
+type qtiChoice struct {
+	Identifier string `xml:"identifier,attr"`
+	Text       string `xml:",chardata"`
+}
+
+type qtiImage struct {
+	Src string `xml:"src,attr"`
+	Alt string `xml:"alt,attr"`
+}
+
+type qtiTextEntry struct {
+	ResponseIdentifier string `xml:"responseIdentifier,attr"`
+}
+
+func (b qtiItemBody) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	paragraph := xml.StartElement{Name: xml.Name{Local: "p"}}
+
+	if err := e.EncodeElement(b.Prompt, paragraph); err != nil {
+		return err
+	}
+
+	if b.Image != "" {
+		image := struct {
+			Image qtiImage `xml:"img"`
+		}{qtiImage{Src: b.Image}}
+
+		if err := e.EncodeElement(image, paragraph); err != nil {
+			return err
+		}
+	}
+
+	if b.Choice != nil {
+		if err := e.EncodeElement(b.Choice, xml.StartElement{Name: xml.Name{Local: "choiceInteraction"}}); err != nil {
+			return err
+		}
+	}
+
+	if b.TextEntry {
+		entry := struct {
+			Entry qtiTextEntry `xml:"textEntryInteraction"`
+		}{qtiTextEntry{ResponseIdentifier: qtiResponseID}}
+
+		if err := e.EncodeElement(entry, paragraph); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML flattens arbitrary XHTML in the item body into a plain prompt,
+// keeping only the first image and the interaction the item is answered with.
+func (b *qtiItemBody) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var prompt strings.Builder
+	var choice *qtiChoice
+
+	depth := 0
+
+	for {
+		token, err := d.Token()
+
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+
+			switch t.Name.Local {
+			case "choiceInteraction":
+				b.Choice = &qtiChoiceInteraction{}
+			case "simpleChoice":
+				if b.Choice != nil {
+					b.Choice.Choices = append(b.Choice.Choices, qtiChoice{Identifier: qtiAttr(t, "identifier")})
+					choice = &b.Choice.Choices[len(b.Choice.Choices)-1]
+				}
+			case "textEntryInteraction", "extendedTextInteraction":
+				b.TextEntry = true
+			case "img":
+				if b.Image == "" {
+					b.Image = qtiAttr(t, "src")
+				}
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				b.Prompt = strings.Join(strings.Fields(prompt.String()), " ")
+
+				if b.Choice != nil {
+					for i := range b.Choice.Choices {
+						b.Choice.Choices[i].Text = strings.Join(strings.Fields(b.Choice.Choices[i].Text), " ")
+					}
+				}
+
+				return nil
+			}
+
+			depth--
+
+			if t.Name.Local == "simpleChoice" {
+				choice = nil
+			} else {
+				prompt.WriteByte(' ')
+			}
+		case xml.CharData:
+			if choice != nil {
+				choice.Text += string(t)
+			} else {
+				prompt.Write(t)
+			}
+		}
+	}
+}
+
+func qtiAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+func encodeQTI(w io.Writer, tree domain.QuizTree) error {
+	archive := zip.NewWriter(w)
+
+	manifest := qtiManifest{
+		Xmlns:      qtiCPNamespace,
+		Identifier: "manifest",
+	}
+
+	test := qtiTest{
+		Xmlns:      qtiNamespace,
+		Identifier: "test",
+		Title:      tree.Title,
+		TestParts: []qtiTestPart{{
+			Identifier:     "part",
+			NavigationMode: "linear",
+			SubmissionMode: "individual",
+			Sections: []qtiSection{{
+				Identifier: "section",
+				Title:      tree.Title,
+				Visible:    true,
+			}},
+		}},
+	}
+
+	section := &test.TestParts[0].Sections[0]
+
+	if tree.Description != "" {
+		section.Rubric = &qtiRubric{View: "candidate", Text: tree.Description}
+	}
+
+	testResource := qtiResource{
+		Identifier: "test",
+		Type:       qtiTestType,
+		Href:       qtiTestPath,
+		Files:      []qtiFile{{Href: qtiTestPath}},
+	}
+
+	for i, question := range tree.Questions {
+		if question.Type == types.Ordering || question.Type == types.Matching || question.Type == types.Numeric || question.Type == types.Essay {
+			return fmt.Errorf("%w: question %d: %s questions are not supported by QTI", http_errors.ErrInvalidFormat, i+1, question.Type)
+		}
+
+		identifier := fmt.Sprintf("item-%d", i+1)
+		href := "items/" + identifier + ".xml"
+
+		if err := writeQTIFile(archive, href, buildQTIItem(identifier, question)); err != nil {
+			return err
+		}
+
+		section.ItemRefs = append(section.ItemRefs, qtiItemRef{Identifier: identifier, Href: href})
+		testResource.Dependencies = append(testResource.Dependencies, qtiDependency{IdentifierRef: identifier})
+		manifest.Resources = append(manifest.Resources, qtiResource{
+			Identifier: identifier,
+			Type:       qtiItemType,
+			Href:       href,
+			Files:      []qtiFile{{Href: href}},
+		})
+	}
+
+	manifest.Resources = append([]qtiResource{testResource}, manifest.Resources...)
+
+	if err := writeQTIFile(archive, qtiTestPath, test); err != nil {
+		return err
+	}
+
+	if err := writeQTIFile(archive, qtiManifestPath, manifest); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func buildQTIItem(identifier string, question domain.QuestionTree) qtiItem {
+	item := qtiItem{
+		Xmlns:      qtiNamespace,
+		Identifier: identifier,
+		Title:      question.Title,
+		Response: qtiResponseDeclaration{
+			Identifier:  qtiResponseID,
+			Cardinality: "single",
+			BaseType:    "identifier",
+		},
+		Outcome: qtiOutcomeDeclaration{
+			Identifier:   "SCORE",
+			Cardinality:  "single",
+			BaseType:     "float",
+			DefaultValue: "0",
+		},
+		Body: qtiItemBody{
+			Prompt: question.Title,
+			Image:  question.Image,
+		},
+		ResponseProcessing: qtiResponseProcessing{Template: qtiMatchCorrect},
+	}
+
+	if question.Points != nil {
+		item.Outcome.NormalMaximum = strconv.Itoa(*question.Points)
+	}
+
+	if question.Type == types.Input {
+		caseSensitive := false
+
+		item.Response.BaseType = "string"
+		item.Response.Mapping = &qtiMapping{}
+		item.Body.TextEntry = true
+		item.ResponseProcessing.Template = qtiMapResponse
+
+		for _, answer := range question.Answers {
+			if len(item.Response.CorrectResponse) == 0 {
+				item.Response.CorrectResponse = []string{answer.Text}
+			}
+
+			item.Response.Mapping.Entries = append(item.Response.Mapping.Entries, qtiMapEntry{
+				MapKey:        answer.Text,
+				MappedValue:   1,
+				CaseSensitive: &caseSensitive,
+			})
+		}
+
+		return item
+	}
+
+	correct, wrong := 0, 0
+
+	for _, answer := range question.Answers {
+		if answer.IsCorrect {
+			correct++
+		} else {
+			wrong++
+		}
+	}
+
+	item.Body.Choice = &qtiChoiceInteraction{
+		ResponseIdentifier: qtiResponseID,
+		MaxChoices:         1,
+	}
+
+	if question.Type == types.Multiple || correct > 1 {
+		item.Response.Cardinality = "multiple"
+		item.Body.Choice.MaxChoices = 0
+	}
+
+	if question.Type == types.Multiple && (question.Scoring == "proportional" || question.Scoring == "penalty") {
+		item.Response.Mapping = &qtiMapping{}
+		item.ResponseProcessing.Template = qtiMapResponse
+	}
+
+	for j, answer := range question.Answers {
+		choiceID := fmt.Sprintf("choice-%d", j+1)
+
+		item.Body.Choice.Choices = append(item.Body.Choice.Choices, qtiChoice{Identifier: choiceID, Text: answer.Text})
+
+		if answer.IsCorrect {
+			item.Response.CorrectResponse = append(item.Response.CorrectResponse, choiceID)
+		}
+
+		if item.Response.Mapping == nil {
+			continue
+		}
+
+		var value float64
+
+		switch {
+		case answer.IsCorrect:
+			value = 1 / float64(correct)
+		case question.Scoring == "penalty":
+			value = -1 / float64(wrong)
+		}
+
+		item.Response.Mapping.Entries = append(item.Response.Mapping.Entries, qtiMapEntry{MapKey: choiceID, MappedValue: value})
+	}
+
+	return item
+}
+
+func writeQTIFile(archive *zip.Writer, name string, v any) error {
+	file, err := archive.Create(name)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(file, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(file)
+	encoder.Indent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func decodeQTI(r io.Reader) (domain.QuizTree, error) {
+	data, err := io.ReadAll(io.LimitReader(r, qtiMaxImportBytes))
+
+	if err != nil {
+		return domain.QuizTree{}, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+
+	if err != nil {
+		return domain.QuizTree{}, err
+	}
+
+	if len(archive.File) > qtiMaxFiles {
+		return domain.QuizTree{}, fmt.Errorf("archive has more than %d files", qtiMaxFiles)
+	}
+
+	var manifest qtiManifest
+
+	if err := readQTIFile(archive, qtiManifestPath, &manifest); err != nil {
+		return domain.QuizTree{}, err
+	}
+
+	var tree domain.QuizTree
+	var hrefs []string
+
+	for _, resource := range manifest.Resources {
+		if !strings.HasPrefix(resource.Type, qtiTestType) {
+			continue
+		}
+
+		var test qtiTest
+
+		if err := readQTIFile(archive, resource.Href, &test); err != nil {
+			return domain.QuizTree{}, err
+		}
+
+		tree.Title = test.Title
+
+		for _, part := range test.TestParts {
+			for _, section := range part.Sections {
+				if section.Rubric != nil && tree.Description == "" {
+					tree.Description = strings.TrimSpace(section.Rubric.Text)
+				}
+
+				for _, ref := range section.ItemRefs {
+					hrefs = append(hrefs, path.Join(path.Dir(resource.Href), ref.Href))
+				}
+			}
+		}
+
+		break
+	}
+
+	if hrefs == nil {
+		for _, resource := range manifest.Resources {
+			if strings.HasPrefix(resource.Type, qtiItemType) {
+				hrefs = append(hrefs, resource.Href)
+			}
+		}
+	}
+
+	for _, href := range hrefs {
+		var item qtiItem
+
+		if err := readQTIFile(archive, href, &item); err != nil {
+			return domain.QuizTree{}, err
+		}
+
+		question, err := parseQTIItem(item)
+
+		if err != nil {
+			return domain.QuizTree{}, err
+		}
+
+		tree.Questions = append(tree.Questions, question)
+	}
+
+	return tree, nil
+}
+
+func parseQTIItem(item qtiItem) (domain.QuestionTree, error) {
+	question := domain.QuestionTree{}
+	question.Title = item.Body.Prompt
+	question.Image = item.Body.Image
+
+	if question.Title == "" {
+		question.Title = item.Title
+	}
+
+	if item.Outcome.NormalMaximum != "" {
+		points, err := strconv.ParseFloat(item.Outcome.NormalMaximum, 64)
+
+		if err == nil && points >= 0 {
+			value := int(points)
+			question.Points = &value
+		}
+	}
+
+	switch {
+	case item.Body.Choice != nil:
+		question.Type = types.Choice
+
+		if item.Response.Cardinality == "multiple" {
+			question.Type = types.Multiple
+		}
+
+		if question.Type == types.Multiple && item.Response.Mapping != nil {
+			question.Scoring = "proportional"
+
+			for _, entry := range item.Response.Mapping.Entries {
+				if entry.MappedValue < 0 {
+					question.Scoring = "penalty"
+				}
+			}
+		}
+
+		correct := make(map[string]bool, len(item.Response.CorrectResponse))
+
+		for _, value := range item.Response.CorrectResponse {
+			correct[strings.TrimSpace(value)] = true
+		}
+
+		for _, choice := range item.Body.Choice.Choices {
+			question.Answers = append(question.Answers, domain.AnswerTree{Answer: domain.Answer{
+				Text:      choice.Text,
+				IsCorrect: correct[choice.Identifier],
+			}})
+		}
+	case item.Body.TextEntry:
+		question.Type = types.Input
+		values := make([]string, 0)
+
+		for _, value := range item.Response.CorrectResponse {
+			values = append(values, strings.TrimSpace(value))
+		}
+
+		if item.Response.Mapping != nil {
+			for _, entry := range item.Response.Mapping.Entries {
+				if entry.MappedValue > 0 {
+					values = append(values, entry.MapKey)
+				}
+			}
+		}
+
+		seen := make(map[string]bool, len(values))
+
+		for _, value := range values {
+			if seen[strings.ToLower(value)] {
+				continue
+			}
+
+			seen[strings.ToLower(value)] = true
+			question.Answers = append(question.Answers, domain.AnswerTree{Answer: domain.Answer{Text: value, IsCorrect: true}})
+		}
+	default:
+		return domain.QuestionTree{}, fmt.Errorf("item %q has an unsupported interaction", item.Identifier)
+	}
+
+	return question, nil
+}
+
+func readQTIFile(archive *zip.Reader, name string, v any) error {
+	name = path.Clean(name)
+
+	for _, file := range archive.File {
+		if path.Clean(file.Name) != name {
+			continue
+		}
+
+		if file.UncompressedSize64 > qtiMaxFileBytes {
+			return fmt.Errorf("%s is too large", name)
+		}
+
+		rc, err := file.Open()
+
+		if err != nil {
+			return err
+		}
+
+		defer rc.Close()
+
+		// The header size can lie, so the reader is capped as well.
+		return xml.NewDecoder(io.LimitReader(rc, qtiMaxFileBytes)).Decode(v)
+	}
+
+	return errors.New("missing " + name)
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"bytes"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	quizService "github.com/blazee5/quizmaster-backend/internal/quiz"
+	"github.com/blazee5/quizmaster-backend/internal/quiz/format"
 	"github.com/blazee5/quizmaster-backend/lib/http_errors"
 	"github.com/blazee5/quizmaster-backend/lib/response"
 	"github.com/go-playground/validator/v10"
@@ -35,9 +38,11 @@ func NewHandler(log *zap.SugaredLogger, service quizService.Service, tracer trac
 // @Param title query string false "title"
 // @Param sortBy query string false "sortBy"
 // @Param sortDir query string false "sortDir"
+// @Param availability query string false "open or upcoming"
 // @Param size query int false "size"
 // @Param page query int false "page"
 // @Success 200 {object} string
+// @Failure 400 {object} string
 // @Failure 500 {object} string
 // @Router /quiz [get]
 func (h *Handler) GetAllQuizzes(c echo.Context) error {
@@ -47,6 +52,13 @@ func (h *Handler) GetAllQuizzes(c echo.Context) error {
 	title := c.QueryParam("title")
 	sortBy := c.QueryParam("sortBy")
 	sortDir := c.QueryParam("sortDir")
+	availability := c.QueryParam("availability")
+
+	if availability != "" && availability != "open" && availability != "upcoming" {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid availability",
+		})
+	}
 
 	page, err := strconv.Atoi(c.QueryParam("page"))
 
@@ -60,7 +72,7 @@ func (h *Handler) GetAllQuizzes(c echo.Context) error {
 		size = 10
 	}
 
-	quizzes, err := h.service.GetAll(ctx, title, sortBy, sortDir, page, size)
+	quizzes, err := h.service.GetAll(ctx, title, sortBy, sortDir, availability, page, size)
 
 	if err != nil {
 		h.log.Infof("error while get all quizzes: %s", err)
@@ -83,8 +95,10 @@ func (h *Handler) GetAllQuizzes(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path int true "quizID"
+// @Param X-Access-Code header string false "Access code"
 // @Success 200 {object} string
 // @Failure 400 {object} string
+// @Failure 403 {object} string
 // @Failure 404 {object} string
 // @Failure 500 {object} string
 // @Router /quiz/{id} [get]
@@ -100,7 +114,9 @@ func (h *Handler) GetQuiz(c echo.Context) error {
 		})
 	}
 
-	quiz, err := h.service.GetByID(ctx, id)
+	userID := c.Get("userID").(int)
+
+	quiz, err := h.service.GetByID(ctx, id, userID, c.Request().Header.Get("X-Access-Code"))
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return c.JSON(http.StatusNotFound, echo.Map{
@@ -108,6 +124,18 @@ func (h *Handler) GetQuiz(c echo.Context) error {
 		})
 	}
 
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrAccessCodeRequired) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "access code required",
+		})
+	}
+
 	if err != nil {
 		h.log.Infof("error while get quiz: %s", err)
 
@@ -158,6 +186,12 @@ func (h *Handler) CreateQuiz(c echo.Context) error {
 
 	id, err := h.service.Create(ctx, userID, input)
 
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
 	if err != nil {
 		h.log.Infof("error while create quiz: %s", err)
 
@@ -174,26 +208,172 @@ func (h *Handler) CreateQuiz(c echo.Context) error {
 	})
 }
 
-// @Summary Update quiz
+// @Summary Import quiz
 // @Tags quiz
-// @Description Update quiz
-// @ID update-quiz
+// @Description Import quiz with questions and answers from JSON, GIFT or QTI 2.1
+// @ID import-quiz
+// @Accept mpfd
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param format query string false "json, gift or qti"
+// @Param title formData string false "title"
+// @Param file formData file true "file"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/import [post]
+func (h *Handler) ImportQuiz(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.ImportQuiz")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+
+	importFormat := c.QueryParam("format")
+
+	if importFormat == "" {
+		importFormat = format.JSON
+	}
+
+	file, err := c.FormFile("file")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "file is required",
+		})
+	}
+
+	src, err := file.Open()
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "bad request",
+		})
+	}
+
+	defer src.Close()
+
+	input, err := format.Decode(src, importFormat)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if title := c.FormValue("title"); title != "" {
+		input.Title = title
+	}
+
+	if err := c.Validate(&input); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": response.ValidationError(validateErr),
+		})
+	}
+
+	id, err := h.service.CreateTree(ctx, userID, input)
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while import quiz: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"id": id,
+	})
+}
+
+// @Summary Create quiz tree
+// @Tags quiz
+// @Description Create quiz with all questions and answers in one request
+// @ID create-quiz-tree
+// @Accept json
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param quiz body domain.QuizTree true "Quiz tree"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/tree [post]
+func (h *Handler) CreateQuizTree(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.CreateQuizTree")
+	defer span.End()
+
+	var input domain.QuizTree
+
+	userID := c.Get("userID").(int)
+
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "bad request",
+		})
+	}
+
+	if err := c.Validate(&input); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": response.ValidationError(validateErr),
+		})
+	}
+
+	id, err := h.service.CreateTree(ctx, userID, input)
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while create quiz tree: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"id": id,
+	})
+}
+
+// @Summary Replace quiz tree
+// @Tags quiz
+// @Description Replace quiz with all questions and answers in one request. Questions and answers with an id are updated, without one are created, missing ones are deleted
+// @ID replace-quiz-tree
 // @Accept json
 // @Produce json
 // @Authorization BearerAuth "Authorization"
-// @Param quiz body domain.Quiz true "Quiz"
 // @Param id path int true "quizID"
+// @Param quiz body domain.QuizTree true "Quiz tree"
 // @Success 200 {object} string
 // @Failure 400 {object} string
 // @Failure 403 {object} string
 // @Failure 404 {object} string
 // @Failure 500 {object} string
-// @Router /quiz/{id} [put]
-func (h *Handler) UpdateQuiz(c echo.Context) error {
-	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.UpdateQuiz")
+// @Router /quiz/{id}/tree [put]
+func (h *Handler) ReplaceQuizTree(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.ReplaceQuizTree")
 	defer span.End()
 
-	var input domain.Quiz
+	var input domain.QuizTree
 
 	id, err := strconv.Atoi(c.Param("id"))
 	userID := c.Get("userID").(int)
@@ -218,7 +398,139 @@ func (h *Handler) UpdateQuiz(c echo.Context) error {
 		})
 	}
 
-	err = h.service.Update(ctx, userID, id, input)
+	err = h.service.ReplaceTree(ctx, userID, id, input)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while replace quiz tree: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.String(http.StatusOK, "OK")
+}
+
+// @Summary Export quiz
+// @Tags quiz
+// @Description Export quiz with questions and answers as JSON, GIFT or QTI 2.1
+// @ID export-quiz
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param id path int true "quizID"
+// @Param format query string false "json, gift or qti"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/{id}/export [get]
+func (h *Handler) ExportQuiz(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.ExportQuiz")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid id",
+		})
+	}
+
+	exportFormat := c.QueryParam("format")
+
+	if exportFormat == "" {
+		exportFormat = format.JSON
+	}
+
+	var buf bytes.Buffer
+
+	err = h.service.Export(ctx, userID, id, exportFormat, &buf)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrInvalidFormat) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while export quiz: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"quiz-%d.%s\"", id, format.Extension(exportFormat)))
+
+	return c.Blob(http.StatusOK, format.ContentType(exportFormat), buf.Bytes())
+}
+
+// @Summary Clone quiz
+// @Tags quiz
+// @Description Copy quiz with its questions, answers and images into a new draft
+// @ID clone-quiz
+// @Accept json
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param id path int true "quizID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/{id}/clone [post]
+func (h *Handler) CloneQuiz(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.CloneQuiz")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	id, err := h.service.Clone(ctx, userID, quizID)
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return c.JSON(http.StatusNotFound, echo.Map{
@@ -232,6 +544,401 @@ func (h *Handler) UpdateQuiz(c echo.Context) error {
 		})
 	}
 
+	if errors.Is(err, http_errors.ErrAccessCodeRequired) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "access code required",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while clone quiz: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"id": id,
+	})
+}
+
+// @Summary Publish quiz
+// @Tags quiz
+// @Description Publish current questions as a new quiz version
+// @ID publish-quiz
+// @Accept json
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param id path int true "quizID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/{id}/publish [post]
+func (h *Handler) PublishQuiz(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.PublishQuiz")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	versionID, err := h.service.Publish(ctx, userID, quizID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while publish quiz: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"version_id": versionID,
+	})
+}
+
+// @Summary Archive quiz
+// @Tags quiz
+// @Description Archive quiz
+// @ID archive-quiz
+// @Accept json
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param id path int true "quizID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/{id}/archive [post]
+func (h *Handler) ArchiveQuiz(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.ArchiveQuiz")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	err = h.service.Archive(ctx, userID, quizID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while archive quiz: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.String(http.StatusOK, "OK")
+}
+
+// @Summary Get invites
+// @Tags quiz
+// @Description Get users invited to the quiz
+// @ID get-invites
+// @Accept json
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param id path int true "quizID"
+// @Success 200 {object} []models.QuizInvite
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/{id}/invites [get]
+func (h *Handler) GetInvites(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.GetInvites")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	invites, err := h.service.GetInvites(ctx, userID, quizID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while get quiz invites: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, invites)
+}
+
+// @Summary Add invite
+// @Tags quiz
+// @Description Invite user to the quiz by email
+// @ID add-invite
+// @Accept json
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param id path int true "quizID"
+// @Param invite body domain.QuizInvite true "Invite"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/{id}/invites [post]
+func (h *Handler) AddInvite(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.AddInvite")
+	defer span.End()
+
+	var input domain.QuizInvite
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "bad request",
+		})
+	}
+
+	if err := c.Validate(&input); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": response.ValidationError(validateErr),
+		})
+	}
+
+	err = h.service.AddInvite(ctx, userID, quizID, input)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz or user not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while add quiz invite: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.String(http.StatusOK, "OK")
+}
+
+// @Summary Delete invite
+// @Tags quiz
+// @Description Remove user from the quiz invite list
+// @ID delete-invite
+// @Accept json
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param id path int true "quizID"
+// @Param userID path int true "userID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/{id}/invites/{userID} [delete]
+func (h *Handler) DeleteInvite(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.DeleteInvite")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	inviteeID, err := strconv.Atoi(c.Param("userID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid user id",
+		})
+	}
+
+	err = h.service.DeleteInvite(ctx, userID, quizID, inviteeID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "invite not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while delete quiz invite: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.String(http.StatusOK, "OK")
+}
+
+// @Summary Update quiz
+// @Tags quiz
+// @Description Update quiz
+// @ID update-quiz
+// @Accept json
+// @Produce json
+// @Authorization BearerAuth "Authorization"
+// @Param quiz body domain.UpdateQuiz true "Quiz"
+// @Param id path int true "quizID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /quiz/{id} [put]
+func (h *Handler) UpdateQuiz(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "quiz.UpdateQuiz")
+	defer span.End()
+
+	var input domain.UpdateQuiz
+
+	id, err := strconv.Atoi(c.Param("id"))
+	userID := c.Get("userID").(int)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid id",
+		})
+	}
+
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "bad request",
+		})
+	}
+
+	if err := c.Validate(&input); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": response.ValidationError(validateErr),
+		})
+	}
+
+	err = h.service.Update(ctx, userID, id, input)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
 	if err != nil {
 		h.log.Infof("error while update quiz: %s", err)
 
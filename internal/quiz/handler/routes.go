@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/blazee5/quizmaster-backend/internal/middleware"
+	questionRepo "github.com/blazee5/quizmaster-backend/internal/question/repository"
 	quizRepo "github.com/blazee5/quizmaster-backend/internal/quiz/repository"
 	quizService "github.com/blazee5/quizmaster-backend/internal/quiz/service"
 	"github.com/blazee5/quizmaster-backend/internal/user/repository"
@@ -20,14 +21,25 @@ func InitQuizRoutes(quizGroup *echo.Group, log *zap.SugaredLogger, db *sqlx.DB,
 	quizElasticRepos := quizRepo.NewElasticRepository(esClient, tracer)
 	quizAWSRepos := quizRepo.NewAWSRepository(awsClient)
 	userRedisRepos := repository.NewUserRedisRepo(rdb)
-	quizServices := quizService.NewService(log, quizRepos, quizRedisRepos, userRedisRepos, quizElasticRepos, quizAWSRepos, tracer)
+	questionRepos := questionRepo.NewRepository(db, tracer)
+	quizServices := quizService.NewService(log, quizRepos, quizRedisRepos, userRedisRepos, quizElasticRepos, quizAWSRepos, questionRepos, tracer)
 	handlers := NewHandler(log, quizServices, tracer)
 
 	quizGroup.POST("", handlers.CreateQuiz, middleware.AuthMiddleware)
+	quizGroup.POST("/import", handlers.ImportQuiz, middleware.AuthMiddleware)
+	quizGroup.POST("/tree", handlers.CreateQuizTree, middleware.AuthMiddleware)
 	quizGroup.POST("/:id/image", handlers.UploadImage, middleware.AuthMiddleware)
+	quizGroup.POST("/:id/clone", handlers.CloneQuiz, middleware.AuthMiddleware)
+	quizGroup.POST("/:id/publish", handlers.PublishQuiz, middleware.AuthMiddleware)
+	quizGroup.POST("/:id/archive", handlers.ArchiveQuiz, middleware.AuthMiddleware)
+	quizGroup.POST("/:id/invites", handlers.AddInvite, middleware.AuthMiddleware)
 	quizGroup.GET("", handlers.GetAllQuizzes)
-	quizGroup.GET("/:id", handlers.GetQuiz)
+	quizGroup.GET("/:id", handlers.GetQuiz, middleware.OptionalAuthMiddleware)
+	quizGroup.GET("/:id/invites", handlers.GetInvites, middleware.AuthMiddleware)
+	quizGroup.GET("/:id/export", handlers.ExportQuiz, middleware.AuthMiddleware)
 	quizGroup.PUT("/:id", handlers.UpdateQuiz, middleware.AuthMiddleware)
+	quizGroup.PUT("/:id/tree", handlers.ReplaceQuizTree, middleware.AuthMiddleware)
 	quizGroup.DELETE("/:id", handlers.DeleteQuiz, middleware.AuthMiddleware)
 	quizGroup.DELETE("/:id/image", handlers.DeleteImage, middleware.AuthMiddleware)
+	quizGroup.DELETE("/:id/invites/:userID", handlers.DeleteInvite, middleware.AuthMiddleware)
 }
@@ -0,0 +1,46 @@
+package media
+
+import (
+	"context"
+	"github.com/blazee5/quizmaster-backend/internal/quiz"
+	"go.uber.org/zap"
+)
+
+type FileDeleter interface {
+	DeleteFile(ctx context.Context, fileName string) error
+}
+
+// DeleteUnused removes the objects that were dropped from the draft of a quiz.
+// Objects still referenced by a published version are kept, attempts pinned to
+// that version and their reviews keep showing them. They are removed together
+// with the quiz. Failures are only logged, the records that used the objects are
+// already gone.
+func DeleteUnused(ctx context.Context, log *zap.SugaredLogger, repo quiz.Repository, awsRepo FileDeleter, quizID int, fileNames []string) {
+	if len(fileNames) == 0 {
+		return
+	}
+
+	versionFiles, err := repo.GetVersionFiles(ctx, quizID)
+
+	if err != nil {
+		log.Infof("error while get version files of quiz %d: %v", quizID, err)
+
+		return
+	}
+
+	used := make(map[string]bool, len(versionFiles))
+
+	for _, fileName := range versionFiles {
+		used[fileName] = true
+	}
+
+	for _, fileName := range fileNames {
+		if fileName == "" || used[fileName] {
+			continue
+		}
+
+		if err := awsRepo.DeleteFile(ctx, fileName); err != nil {
+			log.Infof("error while delete file %s: %v", fileName, err)
+		}
+	}
+}
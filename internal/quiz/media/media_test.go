@@ -0,0 +1,37 @@
+package media
+
+import (
+	"context"
+	"errors"
+	mock_quiz "github.com/blazee5/quizmaster-backend/internal/quiz/mock"
+	"github.com/blazee5/quizmaster-backend/lib/logger"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestDeleteUnused(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	repo := mock_quiz.NewMockRepository(ctrl)
+	awsRepo := mock_quiz.NewMockAWSRepository(ctrl)
+
+	repo.EXPECT().GetVersionFiles(gomock.Any(), 1).Return([]string{"published.png"}, nil)
+	awsRepo.EXPECT().DeleteFile(gomock.Any(), "draft.png").Return(nil)
+
+	DeleteUnused(context.Background(), logger.NewLogger(), repo, awsRepo, 1, []string{"published.png", "draft.png", ""})
+}
+
+func TestDeleteUnusedKeepsFilesOnError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	repo := mock_quiz.NewMockRepository(ctrl)
+	awsRepo := mock_quiz.NewMockAWSRepository(ctrl)
+
+	repo.EXPECT().GetVersionFiles(gomock.Any(), 1).Return(nil, errors.New("connection refused"))
+
+	DeleteUnused(context.Background(), logger.NewLogger(), repo, awsRepo, 1, []string{"draft.png"})
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/quiz/elastic_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/quiz/elastic_repository.go -destination internal/quiz/mock/elastic_repository_mock.go
+//
+// Package mock_quiz is a generated GoMock package.
+package mock_quiz
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/blazee5/quizmaster-backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockElasticRepository is a mock of ElasticRepository interface.
+type MockElasticRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockElasticRepositoryMockRecorder
+}
+
+// MockElasticRepositoryMockRecorder is the mock recorder for MockElasticRepository.
+type MockElasticRepositoryMockRecorder struct {
+	mock *MockElasticRepository
+}
+
+// NewMockElasticRepository creates a new mock instance.
+func NewMockElasticRepository(ctrl *gomock.Controller) *MockElasticRepository {
+	mock := &MockElasticRepository{ctrl: ctrl}
+	mock.recorder = &MockElasticRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockElasticRepository) EXPECT() *MockElasticRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIndex mocks base method.
+func (m *MockElasticRepository) CreateIndex(ctx context.Context, input models.Quiz) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockElasticRepositoryMockRecorder) CreateIndex(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockElasticRepository)(nil).CreateIndex), ctx, input)
+}
+
+// DeleteIndex mocks base method.
+func (m *MockElasticRepository) DeleteIndex(ctx context.Context, ID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIndex", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIndex indicates an expected call of DeleteIndex.
+func (mr *MockElasticRepositoryMockRecorder) DeleteIndex(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIndex", reflect.TypeOf((*MockElasticRepository)(nil).DeleteIndex), ctx, ID)
+}
+
+// SearchIndex mocks base method.
+func (m *MockElasticRepository) SearchIndex(ctx context.Context, input, sortBy, sortDir, availability string, offset, size int) (models.QuizList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchIndex", ctx, input, sortBy, sortDir, availability, offset, size)
+	ret0, _ := ret[0].(models.QuizList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchIndex indicates an expected call of SearchIndex.
+func (mr *MockElasticRepositoryMockRecorder) SearchIndex(ctx, input, sortBy, sortDir, availability, offset, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIndex", reflect.TypeOf((*MockElasticRepository)(nil).SearchIndex), ctx, input, sortBy, sortDir, availability, offset, size)
+}
+
+// UpdateIndex mocks base method.
+func (m *MockElasticRepository) UpdateIndex(ctx context.Context, id int, input models.Quiz) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIndex", ctx, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIndex indicates an expected call of UpdateIndex.
+func (mr *MockElasticRepositoryMockRecorder) UpdateIndex(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIndex", reflect.TypeOf((*MockElasticRepository)(nil).UpdateIndex), ctx, id, input)
+}
@@ -0,0 +1,306 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/quiz/pg_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/quiz/pg_repository.go -destination internal/quiz/mock/pg_repository_mock.go
+//
+// Package mock_quiz is a generated GoMock package.
+package mock_quiz
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/blazee5/quizmaster-backend/internal/domain"
+	models "github.com/blazee5/quizmaster-backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddInvite mocks base method.
+func (m *MockRepository) AddInvite(ctx context.Context, quizID int, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInvite", ctx, quizID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddInvite indicates an expected call of AddInvite.
+func (mr *MockRepositoryMockRecorder) AddInvite(ctx, quizID, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInvite", reflect.TypeOf((*MockRepository)(nil).AddInvite), ctx, quizID, email)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, userID int, input domain.Quiz) (models.Quiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, input)
+	ret0, _ := ret[0].(models.Quiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, userID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, userID, input)
+}
+
+// CreateTree mocks base method.
+func (m *MockRepository) CreateTree(ctx context.Context, userID int, input domain.QuizTree) (models.Quiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTree", ctx, userID, input)
+	ret0, _ := ret[0].(models.Quiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTree indicates an expected call of CreateTree.
+func (mr *MockRepositoryMockRecorder) CreateTree(ctx, userID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTree", reflect.TypeOf((*MockRepository)(nil).CreateTree), ctx, userID, input)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, quizID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, quizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, quizID)
+}
+
+// DeleteImage mocks base method.
+func (m *MockRepository) DeleteImage(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockRepositoryMockRecorder) DeleteImage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockRepository)(nil).DeleteImage), ctx, id)
+}
+
+// DeleteInvite mocks base method.
+func (m *MockRepository) DeleteInvite(ctx context.Context, quizID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvite", ctx, quizID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvite indicates an expected call of DeleteInvite.
+func (mr *MockRepositoryMockRecorder) DeleteInvite(ctx, quizID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvite", reflect.TypeOf((*MockRepository)(nil).DeleteInvite), ctx, quizID, userID)
+}
+
+// GetAll mocks base method.
+func (m *MockRepository) GetAll(ctx context.Context, sortBy, sortDir, availability string, page, size int) (models.QuizList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, sortBy, sortDir, availability, page, size)
+	ret0, _ := ret[0].(models.QuizList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRepositoryMockRecorder) GetAll(ctx, sortBy, sortDir, availability, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), ctx, sortBy, sortDir, availability, page, size)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id int) (models.Quiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.Quiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetInvites mocks base method.
+func (m *MockRepository) GetInvites(ctx context.Context, quizID int) ([]models.QuizInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvites", ctx, quizID)
+	ret0, _ := ret[0].([]models.QuizInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvites indicates an expected call of GetInvites.
+func (mr *MockRepositoryMockRecorder) GetInvites(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvites", reflect.TypeOf((*MockRepository)(nil).GetInvites), ctx, quizID)
+}
+
+// GetOwnedFiles mocks base method.
+func (m *MockRepository) GetOwnedFiles(ctx context.Context, userID int, fileNames []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnedFiles", ctx, userID, fileNames)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnedFiles indicates an expected call of GetOwnedFiles.
+func (mr *MockRepositoryMockRecorder) GetOwnedFiles(ctx, userID, fileNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnedFiles", reflect.TypeOf((*MockRepository)(nil).GetOwnedFiles), ctx, userID, fileNames)
+}
+
+// GetVersion mocks base method.
+func (m *MockRepository) GetVersion(ctx context.Context, versionID int) (models.QuizVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", ctx, versionID)
+	ret0, _ := ret[0].(models.QuizVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockRepositoryMockRecorder) GetVersion(ctx, versionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockRepository)(nil).GetVersion), ctx, versionID)
+}
+
+// GetVersionFiles mocks base method.
+func (m *MockRepository) GetVersionFiles(ctx context.Context, quizID int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersionFiles", ctx, quizID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersionFiles indicates an expected call of GetVersionFiles.
+func (mr *MockRepositoryMockRecorder) GetVersionFiles(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersionFiles", reflect.TypeOf((*MockRepository)(nil).GetVersionFiles), ctx, quizID)
+}
+
+// HasInvite mocks base method.
+func (m *MockRepository) HasInvite(ctx context.Context, quizID, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasInvite", ctx, quizID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasInvite indicates an expected call of HasInvite.
+func (mr *MockRepositoryMockRecorder) HasInvite(ctx, quizID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasInvite", reflect.TypeOf((*MockRepository)(nil).HasInvite), ctx, quizID, userID)
+}
+
+// Publish mocks base method.
+func (m *MockRepository) Publish(ctx context.Context, quizID int, questions []models.QuestionWithAnswers) (models.Quiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, quizID, questions)
+	ret0, _ := ret[0].(models.Quiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockRepositoryMockRecorder) Publish(ctx, quizID, questions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockRepository)(nil).Publish), ctx, quizID, questions)
+}
+
+// ReplaceTree mocks base method.
+func (m *MockRepository) ReplaceTree(ctx context.Context, quizID int, input domain.QuizTree) (models.Quiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTree", ctx, quizID, input)
+	ret0, _ := ret[0].(models.Quiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceTree indicates an expected call of ReplaceTree.
+func (mr *MockRepositoryMockRecorder) ReplaceTree(ctx, quizID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTree", reflect.TypeOf((*MockRepository)(nil).ReplaceTree), ctx, quizID, input)
+}
+
+// SetStatus mocks base method.
+func (m *MockRepository) SetStatus(ctx context.Context, quizID int, status string) (models.Quiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, quizID, status)
+	ret0, _ := ret[0].(models.Quiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockRepositoryMockRecorder) SetStatus(ctx, quizID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockRepository)(nil).SetStatus), ctx, quizID, status)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, quizID int, input domain.UpdateQuiz) (models.Quiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, quizID, input)
+	ret0, _ := ret[0].(models.Quiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, quizID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, quizID, input)
+}
+
+// UploadImage mocks base method.
+func (m *MockRepository) UploadImage(ctx context.Context, id int, filename string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", ctx, id, filename)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockRepositoryMockRecorder) UploadImage(ctx, id, filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockRepository)(nil).UploadImage), ctx, id, filename)
+}
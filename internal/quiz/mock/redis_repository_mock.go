@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/quiz/redis_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/quiz/redis_repository.go -destination internal/quiz/mock/redis_repository_mock.go
+//
+// Package mock_quiz is a generated GoMock package.
+package mock_quiz
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/blazee5/quizmaster-backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisRepository is a mock of RedisRepository interface.
+type MockRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisRepositoryMockRecorder
+}
+
+// MockRedisRepositoryMockRecorder is the mock recorder for MockRedisRepository.
+type MockRedisRepositoryMockRecorder struct {
+	mock *MockRedisRepository
+}
+
+// NewMockRedisRepository creates a new mock instance.
+func NewMockRedisRepository(ctrl *gomock.Controller) *MockRedisRepository {
+	mock := &MockRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisRepository) EXPECT() *MockRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteQuizCtx mocks base method.
+func (m *MockRedisRepository) DeleteQuizCtx(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuizCtx", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuizCtx indicates an expected call of DeleteQuizCtx.
+func (mr *MockRedisRepositoryMockRecorder) DeleteQuizCtx(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuizCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteQuizCtx), ctx, key)
+}
+
+// GetByIDCtx mocks base method.
+func (m *MockRedisRepository) GetByIDCtx(ctx context.Context, key string) (*models.Quiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDCtx", ctx, key)
+	ret0, _ := ret[0].(*models.Quiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDCtx indicates an expected call of GetByIDCtx.
+func (mr *MockRedisRepositoryMockRecorder) GetByIDCtx(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetByIDCtx), ctx, key)
+}
+
+// SetQuizCtx mocks base method.
+func (m *MockRedisRepository) SetQuizCtx(ctx context.Context, key string, seconds int, quiz *models.Quiz) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetQuizCtx", ctx, key, seconds, quiz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetQuizCtx indicates an expected call of SetQuizCtx.
+func (mr *MockRedisRepositoryMockRecorder) SetQuizCtx(ctx, key, seconds, quiz any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQuizCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetQuizCtx), ctx, key, seconds, quiz)
+}
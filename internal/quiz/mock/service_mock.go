@@ -10,6 +10,7 @@ package mock_quiz
 
 import (
 	context "context"
+	io "io"
 	multipart "mime/multipart"
 	reflect "reflect"
 
@@ -41,6 +42,49 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// AddInvite mocks base method.
+func (m *MockService) AddInvite(ctx context.Context, userID, quizID int, input domain.QuizInvite) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInvite", ctx, userID, quizID, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddInvite indicates an expected call of AddInvite.
+func (mr *MockServiceMockRecorder) AddInvite(ctx, userID, quizID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInvite", reflect.TypeOf((*MockService)(nil).AddInvite), ctx, userID, quizID, input)
+}
+
+// Archive mocks base method.
+func (m *MockService) Archive(ctx context.Context, userID, quizID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, userID, quizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockServiceMockRecorder) Archive(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockService)(nil).Archive), ctx, userID, quizID)
+}
+
+// Clone mocks base method.
+func (m *MockService) Clone(ctx context.Context, userID, quizID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", ctx, userID, quizID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockServiceMockRecorder) Clone(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockService)(nil).Clone), ctx, userID, quizID)
+}
+
 // Create mocks base method.
 func (m *MockService) Create(ctx context.Context, userID int, input domain.Quiz) (int, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +100,21 @@ func (mr *MockServiceMockRecorder) Create(ctx, userID, input any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, userID, input)
 }
 
+// CreateTree mocks base method.
+func (m *MockService) CreateTree(ctx context.Context, userID int, input domain.QuizTree) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTree", ctx, userID, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTree indicates an expected call of CreateTree.
+func (mr *MockServiceMockRecorder) CreateTree(ctx, userID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTree", reflect.TypeOf((*MockService)(nil).CreateTree), ctx, userID, input)
+}
+
 // Delete mocks base method.
 func (m *MockService) Delete(ctx context.Context, userID, quizID int) error {
 	m.ctrl.T.Helper()
@@ -84,38 +143,110 @@ func (mr *MockServiceMockRecorder) DeleteImage(ctx, userID, quizID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockService)(nil).DeleteImage), ctx, userID, quizID)
 }
 
+// DeleteInvite mocks base method.
+func (m *MockService) DeleteInvite(ctx context.Context, userID, quizID, inviteeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvite", ctx, userID, quizID, inviteeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvite indicates an expected call of DeleteInvite.
+func (mr *MockServiceMockRecorder) DeleteInvite(ctx, userID, quizID, inviteeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvite", reflect.TypeOf((*MockService)(nil).DeleteInvite), ctx, userID, quizID, inviteeID)
+}
+
+// Export mocks base method.
+func (m *MockService) Export(ctx context.Context, userID, quizID int, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, userID, quizID, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockServiceMockRecorder) Export(ctx, userID, quizID, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockService)(nil).Export), ctx, userID, quizID, format, w)
+}
+
 // GetAll mocks base method.
-func (m *MockService) GetAll(ctx context.Context, title, sortBy, sortDir string, page, size int) (models.QuizList, error) {
+func (m *MockService) GetAll(ctx context.Context, title, sortBy, sortDir, availability string, page, size int) (models.QuizList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, title, sortBy, sortDir, page, size)
+	ret := m.ctrl.Call(m, "GetAll", ctx, title, sortBy, sortDir, availability, page, size)
 	ret0, _ := ret[0].(models.QuizList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockServiceMockRecorder) GetAll(ctx, title, sortBy, sortDir, page, size any) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAll(ctx, title, sortBy, sortDir, availability, page, size any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, title, sortBy, sortDir, page, size)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, title, sortBy, sortDir, availability, page, size)
 }
 
 // GetByID mocks base method.
-func (m *MockService) GetByID(ctx context.Context, id int) (models.Quiz, error) {
+func (m *MockService) GetByID(ctx context.Context, id, userID int, accessCode string) (models.Quiz, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, userID, accessCode)
 	ret0, _ := ret[0].(models.Quiz)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetByID(ctx, id, userID, accessCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, id, userID, accessCode)
+}
+
+// GetInvites mocks base method.
+func (m *MockService) GetInvites(ctx context.Context, userID, quizID int) ([]models.QuizInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvites", ctx, userID, quizID)
+	ret0, _ := ret[0].([]models.QuizInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvites indicates an expected call of GetInvites.
+func (mr *MockServiceMockRecorder) GetInvites(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvites", reflect.TypeOf((*MockService)(nil).GetInvites), ctx, userID, quizID)
+}
+
+// Publish mocks base method.
+func (m *MockService) Publish(ctx context.Context, userID, quizID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, userID, quizID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockServiceMockRecorder) Publish(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockService)(nil).Publish), ctx, userID, quizID)
+}
+
+// ReplaceTree mocks base method.
+func (m *MockService) ReplaceTree(ctx context.Context, userID, quizID int, input domain.QuizTree) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTree", ctx, userID, quizID, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTree indicates an expected call of ReplaceTree.
+func (mr *MockServiceMockRecorder) ReplaceTree(ctx, userID, quizID, input any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTree", reflect.TypeOf((*MockService)(nil).ReplaceTree), ctx, userID, quizID, input)
 }
 
 // Update mocks base method.
-func (m *MockService) Update(ctx context.Context, userID, quizID int, input domain.Quiz) error {
+func (m *MockService) Update(ctx context.Context, userID, quizID int, input domain.UpdateQuiz) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, userID, quizID, input)
 	ret0, _ := ret[0].(error)
@@ -8,10 +8,21 @@ import (
 
 type Repository interface {
 	GetByID(ctx context.Context, id int) (models.Quiz, error)
-	GetAll(ctx context.Context, sortBy, sortDir string, page, size int) (models.QuizList, error)
+	GetAll(ctx context.Context, sortBy, sortDir, availability string, page, size int) (models.QuizList, error)
 	Create(ctx context.Context, userID int, input domain.Quiz) (models.Quiz, error)
-	Update(ctx context.Context, quizID int, input domain.Quiz) (models.Quiz, error)
+	CreateTree(ctx context.Context, userID int, input domain.QuizTree) (models.Quiz, error)
+	ReplaceTree(ctx context.Context, quizID int, input domain.QuizTree) (models.Quiz, error)
+	Update(ctx context.Context, quizID int, input domain.UpdateQuiz) (models.Quiz, error)
 	Delete(ctx context.Context, quizID int) error
+	Publish(ctx context.Context, quizID int, questions []models.QuestionWithAnswers) (models.Quiz, error)
+	SetStatus(ctx context.Context, quizID int, status string) (models.Quiz, error)
+	GetVersion(ctx context.Context, versionID int) (models.QuizVersion, error)
+	GetVersionFiles(ctx context.Context, quizID int) ([]string, error)
+	GetInvites(ctx context.Context, quizID int) ([]models.QuizInvite, error)
+	AddInvite(ctx context.Context, quizID int, email string) error
+	DeleteInvite(ctx context.Context, quizID, userID int) error
+	HasInvite(ctx context.Context, quizID, userID int) (bool, error)
+	GetOwnedFiles(ctx context.Context, userID int, fileNames []string) ([]string, error)
 	UploadImage(ctx context.Context, id int, filename string) error
 	DeleteImage(ctx context.Context, id int) error
 }
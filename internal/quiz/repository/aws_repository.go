@@ -49,6 +49,24 @@ func (s *AWSRepository) SaveFile(ctx context.Context, fileName, contentType stri
 	return nil
 }
 
+func (s *AWSRepository) CopyFile(ctx context.Context, srcFileName, dstFileName string) error {
+	dst := minio.CopyDestOptions{
+		Bucket: quizBucketName,
+		Object: dstFileName,
+	}
+
+	src := minio.CopySrcOptions{
+		Bucket: quizBucketName,
+		Object: srcFileName,
+	}
+
+	if _, err := s.client.CopyObject(ctx, dst, src); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *AWSRepository) DeleteFile(ctx context.Context, fileName string) error {
 	if err := s.client.RemoveObject(ctx, quizBucketName, fileName, minio.RemoveObjectOptions{}); err != nil {
 		return err
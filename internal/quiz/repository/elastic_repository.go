@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/blazee5/quizmaster-backend/internal/models"
 	"github.com/elastic/go-elasticsearch/v8"
 	"go.opentelemetry.io/otel/codes"
@@ -26,6 +25,9 @@ func (repo *ElasticRepository) CreateIndex(ctx context.Context, input models.Qui
 	ctx, span := repo.tracer.Start(ctx, "quizElasticRepo.CreateIndex")
 	defer span.End()
 
+	// the access code is a secret and search results are public
+	input.AccessCode = ""
+
 	data, err := json.Marshal(input)
 	if err != nil {
 		span.RecordError(err)
@@ -58,10 +60,34 @@ func (repo *ElasticRepository) CreateIndex(ctx context.Context, input models.Qui
 	return nil
 }
 
-func (repo *ElasticRepository) SearchIndex(ctx context.Context, input, sortBy, sortDir string, page, size int) (models.QuizList, error) {
+func (repo *ElasticRepository) SearchIndex(ctx context.Context, input, sortBy, sortDir, availability string, page, size int) (models.QuizList, error) {
 	ctx, span := repo.tracer.Start(ctx, "quizElasticRepo.SearchIndex")
 	defer span.End()
 
+	mustNot := []map[string]any{
+		{
+			"terms": map[string]any{
+				"status": []string{"draft", "archived"},
+			},
+		},
+		{
+			"terms": map[string]any{
+				"visibility": []string{"unlisted", "private", "access_code", "invite"},
+			},
+		},
+	}
+	filter := make([]map[string]any, 0)
+
+	switch availability {
+	case "open":
+		mustNot = append(mustNot,
+			map[string]any{"range": map[string]any{"opens_at": map[string]any{"gt": "now"}}},
+			map[string]any{"range": map[string]any{"closes_at": map[string]any{"lte": "now"}}},
+		)
+	case "upcoming":
+		filter = append(filter, map[string]any{"range": map[string]any{"opens_at": map[string]any{"gt": "now"}}})
+	}
+
 	query := map[string]any{
 		"from": (page - 1) * size,
 		"size": size,
@@ -84,6 +110,9 @@ func (repo *ElasticRepository) SearchIndex(ctx context.Context, input, sortBy, s
 						},
 					},
 				},
+				"minimum_should_match": 1,
+				"must_not":             mustNot,
+				"filter":               filter,
 			},
 		},
 		"sort": []map[string]any{
@@ -163,8 +192,17 @@ func (repo *ElasticRepository) UpdateIndex(ctx context.Context, id int, input mo
 
 	body, err := json.Marshal(map[string]any{
 		"script": map[string]any{
-			"source": fmt.Sprintf("ctx._source.title = '%s'; ctx._source.description = '%s'; ctx._source.image = '%s'", input.Title, input.Description, input.Image),
+			"source": "ctx._source.title = params.title; ctx._source.description = params.description; ctx._source.image = params.image; ctx._source.status = params.status; ctx._source.visibility = params.visibility; ctx._source.remove('access_code'); ctx._source.opens_at = params.opens_at; ctx._source.closes_at = params.closes_at",
 			"lang":   "painless",
+			"params": map[string]any{
+				"title":       input.Title,
+				"description": input.Description,
+				"image":       input.Image,
+				"status":      input.Status,
+				"visibility":  input.Visibility,
+				"opens_at":    input.OpensAt,
+				"closes_at":   input.ClosesAt,
+			},
 		},
 		"query": map[string]any{
 			"match": map[string]any{
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	"github.com/blazee5/quizmaster-backend/internal/models"
@@ -21,7 +22,7 @@ func NewRepository(db *sqlx.DB, tracer trace.Tracer) *Repository {
 	return &Repository{db: db, tracer: tracer}
 }
 
-func (repo *Repository) GetAll(ctx context.Context, sortBy, sortDir string, page, size int) (models.QuizList, error) {
+func (repo *Repository) GetAll(ctx context.Context, sortBy, sortDir, availability string, page, size int) (models.QuizList, error) {
 	ctx, span := repo.tracer.Start(ctx, "quizRepo.GetAll")
 	defer span.End()
 
@@ -32,7 +33,25 @@ func (repo *Repository) GetAll(ctx context.Context, sortBy, sortDir string, page
 		offset = (page - 1) * size
 	}
 
-	err := repo.db.QueryRowxContext(ctx, "SELECT COUNT(*) FROM quizzes").Scan(&total)
+	filter := sq.And{sq.Eq{"status": "published", "visibility": "public"}}
+
+	switch availability {
+	case "open":
+		filter = append(filter, sq.Expr("(opens_at IS NULL OR opens_at <= NOW()) AND (closes_at IS NULL OR closes_at > NOW())"))
+	case "upcoming":
+		filter = append(filter, sq.Expr("opens_at > NOW()"))
+	}
+
+	countSQL, countArgs, err := sq.Select("COUNT(*)").From("quizzes").Where(filter).PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.QuizList{}, err
+	}
+
+	err = repo.db.QueryRowxContext(ctx, countSQL, countArgs...).Scan(&total)
 
 	if err != nil {
 		span.RecordError(err)
@@ -44,8 +63,9 @@ func (repo *Repository) GetAll(ctx context.Context, sortBy, sortDir string, page
 	quizzes := make([]models.Quiz, 0)
 
 	sql, args, err := sq.
-		Select("id", "title", "description", "image", "user_id", "created_at").
+		Select("id", "title", "description", "image", "user_id", "time_limit", "show_answers", "max_attempts", "attempt_cooldown", "score_policy", "shuffle_questions", "shuffle_answers", "pool_size", "pool_by_tag", "visibility", "access_code", "opens_at", "closes_at", "status", "version_id", "created_at").
 		From("quizzes").
+		Where(filter).
 		OrderBy(sortBy + " " + sortDir).
 		Limit(uint64(size)).
 		Offset(uint64(offset)).
@@ -83,9 +103,13 @@ func (repo *Repository) Create(ctx context.Context, userID int, input domain.Qui
 
 	var quiz models.Quiz
 
-	err := repo.db.QueryRowxContext(ctx, `INSERT INTO quizzes (title, description, user_id)
-		VALUES ($1, $2, $3) RETURNING id, title, description, image, user_id, created_at`,
-		input.Title, input.Description, userID).StructScan(&quiz)
+	err := repo.db.QueryRowxContext(ctx, `INSERT INTO quizzes (title, description, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy,
+		shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), 'best'), $9, $10, $11, $12,
+		COALESCE(NULLIF($13, ''), 'public'), CASE WHEN $13 = 'access_code' THEN $14 ELSE '' END, $15, $16)
+		RETURNING id, title, description, image, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy, shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at, status, version_id, created_at`,
+		input.Title, input.Description, userID, input.TimeLimit, input.ShowAnswers, input.MaxAttempts, input.AttemptCooldown, input.ScorePolicy,
+		input.ShuffleQuestions, input.ShuffleAnswers, input.PoolSize, input.PoolByTag, input.Visibility, input.AccessCode, input.OpensAt, input.ClosesAt).StructScan(&quiz)
 
 	if err != nil {
 		span.RecordError(err)
@@ -97,6 +121,278 @@ func (repo *Repository) Create(ctx context.Context, userID int, input domain.Qui
 	return quiz, nil
 }
 
+func (repo *Repository) CreateTree(ctx context.Context, userID int, input domain.QuizTree) (models.Quiz, error) {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.CreateTree")
+	defer span.End()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	defer tx.Rollback()
+
+	var quiz models.Quiz
+
+	err = tx.QueryRowxContext(ctx, `INSERT INTO quizzes (title, description, image, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy,
+		shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE(NULLIF($9, ''), 'best'), $10, $11, $12, $13,
+		COALESCE(NULLIF($14, ''), 'public'), CASE WHEN $14 = 'access_code' THEN $15 ELSE '' END, $16, $17)
+		RETURNING id, title, description, image, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy, shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at, status, version_id, created_at`,
+		input.Title, input.Description, input.Image, userID, input.TimeLimit, input.ShowAnswers, input.MaxAttempts, input.AttemptCooldown, input.ScorePolicy,
+		input.ShuffleQuestions, input.ShuffleAnswers, input.PoolSize, input.PoolByTag, input.Visibility, input.AccessCode, input.OpensAt, input.ClosesAt).StructScan(&quiz)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	if err := insertQuestions(ctx, tx, quiz.ID, input.Questions); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	return quiz, nil
+}
+
+// ReplaceTree updates the quiz and syncs its questions and answers with the tree:
+// items with an ID are updated in place, so attempts that reference them keep
+// their history, items without one are inserted and the rest are deleted.
+func (repo *Repository) ReplaceTree(ctx context.Context, quizID int, input domain.QuizTree) (models.Quiz, error) {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.ReplaceTree")
+	defer span.End()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	defer tx.Rollback()
+
+	var quiz models.Quiz
+
+	err = tx.QueryRowxContext(ctx, `UPDATE quizzes SET
+		title = $1,
+		description = $2,
+		time_limit = $3,
+		show_answers = $4,
+		max_attempts = $5,
+		attempt_cooldown = $6,
+		score_policy = COALESCE(NULLIF($7, ''), score_policy),
+		shuffle_questions = $8,
+		shuffle_answers = $9,
+		pool_size = $10,
+		pool_by_tag = $11,
+		visibility = COALESCE(NULLIF($13, ''), visibility),
+		access_code = CASE WHEN COALESCE(NULLIF($13, ''), visibility) = 'access_code' THEN COALESCE(NULLIF($14, ''), access_code) ELSE '' END,
+		opens_at = $15,
+		closes_at = $16
+		WHERE id = $12
+		RETURNING id, title, description, image, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy, shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at, status, version_id, created_at`,
+		input.Title, input.Description, input.TimeLimit, input.ShowAnswers, input.MaxAttempts, input.AttemptCooldown, input.ScorePolicy,
+		input.ShuffleQuestions, input.ShuffleAnswers, input.PoolSize, input.PoolByTag, quizID, input.Visibility, input.AccessCode, input.OpensAt, input.ClosesAt).StructScan(&quiz)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	var questionIDs []int
+
+	if err := tx.SelectContext(ctx, &questionIDs, "SELECT id FROM questions WHERE quiz_id = $1", quizID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	keep := make(map[int]bool, len(input.Questions))
+	created := make([]domain.QuestionTree, 0)
+
+	for _, question := range input.Questions {
+		if question.ID == 0 {
+			created = append(created, question)
+			continue
+		}
+
+		keep[question.ID] = true
+	}
+
+	for _, id := range questionIDs {
+		if keep[id] {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM questions WHERE id = $1", id); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return models.Quiz{}, err
+		}
+	}
+
+	for _, question := range input.Questions {
+		if question.ID == 0 {
+			continue
+		}
+
+		if err := updateQuestion(ctx, tx, quizID, question); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return models.Quiz{}, err
+		}
+	}
+
+	if err := insertQuestions(ctx, tx, quizID, created); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	return quiz, nil
+}
+
+func insertQuestions(ctx context.Context, tx *sqlx.Tx, quizID int, questions []domain.QuestionTree) error {
+	for _, question := range questions {
+		var questionID int
+
+		err := tx.QueryRowxContext(ctx, `INSERT INTO questions (quiz_id, title, image, audio, type, order_id, scoring, points, tag, match_mode, ignore_accents, typo_tolerance, explanation)
+			VALUES ($1, $2, $3, $4, $5, $6, COALESCE(NULLIF($7, ''), 'all_or_nothing'), COALESCE($8, 1), $9, COALESCE(NULLIF($10, ''), 'exact'), $11, $12, $13) RETURNING id`,
+			quizID, question.Title, question.Image, question.Audio, question.Type, question.OrderID, question.Scoring, question.Points, question.Tag,
+			question.MatchMode, question.IgnoreAccents, question.TypoTolerance, question.Explanation).Scan(&questionID)
+
+		if err != nil {
+			return err
+		}
+
+		for _, answer := range question.Answers {
+			if err := insertAnswer(ctx, tx, questionID, answer); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func updateQuestion(ctx context.Context, tx *sqlx.Tx, quizID int, question domain.QuestionTree) error {
+	res, err := tx.ExecContext(ctx, `UPDATE questions SET
+		title = $1,
+		type = $2,
+		order_id = $3,
+		scoring = COALESCE(NULLIF($4, ''), scoring),
+		points = COALESCE($5, points),
+		tag = $6,
+		match_mode = COALESCE(NULLIF($7, ''), match_mode),
+		ignore_accents = $8,
+		typo_tolerance = $9,
+		explanation = $10 WHERE id = $11 AND quiz_id = $12`,
+		question.Title, question.Type, question.OrderID, question.Scoring, question.Points, question.Tag,
+		question.MatchMode, question.IgnoreAccents, question.TypoTolerance, question.Explanation, question.ID, quizID)
+
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if rows < 1 {
+		return sql.ErrNoRows
+	}
+
+	var answerIDs []int
+
+	if err := tx.SelectContext(ctx, &answerIDs, "SELECT id FROM answers WHERE question_id = $1", question.ID); err != nil {
+		return err
+	}
+
+	keep := make(map[int]bool, len(question.Answers))
+
+	for _, answer := range question.Answers {
+		keep[answer.ID] = true
+	}
+
+	for _, id := range answerIDs {
+		if keep[id] {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM answers WHERE id = $1", id); err != nil {
+			return err
+		}
+	}
+
+	for _, answer := range question.Answers {
+		if answer.ID == 0 {
+			if err := insertAnswer(ctx, tx, question.ID, answer); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		res, err := tx.ExecContext(ctx, "UPDATE answers SET text = $1, is_correct = $2, order_id = $3, match_text = $4 WHERE id = $5 AND question_id = $6",
+			answer.Text, answer.IsCorrect, answer.OrderID, answer.MatchText, answer.ID, question.ID)
+
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+
+		if err != nil {
+			return err
+		}
+
+		if rows < 1 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
+
+func insertAnswer(ctx context.Context, tx *sqlx.Tx, questionID int, answer domain.AnswerTree) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO answers (question_id, text, is_correct, order_id, match_text, image) VALUES ($1, $2, $3, $4, $5, $6)",
+		questionID, answer.Text, answer.IsCorrect, answer.OrderID, answer.MatchText, answer.Image)
+
+	return err
+}
+
 func (repo *Repository) GetByID(ctx context.Context, id int) (models.Quiz, error) {
 	ctx, span := repo.tracer.Start(ctx, "quizRepo.GetByID")
 	defer span.End()
@@ -115,7 +411,7 @@ func (repo *Repository) GetByID(ctx context.Context, id int) (models.Quiz, error
 	return quiz, nil
 }
 
-func (repo *Repository) Update(ctx context.Context, quizID int, input domain.Quiz) (models.Quiz, error) {
+func (repo *Repository) Update(ctx context.Context, quizID int, input domain.UpdateQuiz) (models.Quiz, error) {
 	ctx, span := repo.tracer.Start(ctx, "quizRepo.Update")
 	defer span.End()
 
@@ -123,9 +419,25 @@ func (repo *Repository) Update(ctx context.Context, quizID int, input domain.Qui
 
 	err := repo.db.QueryRowxContext(ctx, `UPDATE quizzes SET
 		title = COALESCE(NULLIF($1, ''), title),
-		description = $2 WHERE id = $3
-		RETURNING id, title, description, image, user_id, created_at`,
-		input.Title, input.Description, quizID).StructScan(&quiz)
+		description = $2,
+		time_limit = COALESCE($3, time_limit),
+		show_answers = COALESCE($4, show_answers),
+		max_attempts = COALESCE($5, max_attempts),
+		attempt_cooldown = COALESCE($6, attempt_cooldown),
+		score_policy = COALESCE(NULLIF($7, ''), score_policy),
+		shuffle_questions = COALESCE($8, shuffle_questions),
+		shuffle_answers = COALESCE($9, shuffle_answers),
+		pool_size = COALESCE($10, pool_size),
+		pool_by_tag = COALESCE($11, pool_by_tag),
+		visibility = COALESCE(NULLIF($13, ''), visibility),
+		access_code = CASE WHEN COALESCE(NULLIF($13, ''), visibility) = 'access_code' THEN COALESCE(NULLIF($14, ''), access_code) ELSE '' END,
+		opens_at = CASE WHEN $17 THEN NULL ELSE COALESCE($15, opens_at) END,
+		closes_at = CASE WHEN $18 THEN NULL ELSE COALESCE($16, closes_at) END
+		WHERE id = $12
+		RETURNING id, title, description, image, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy, shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at, status, version_id, created_at`,
+		input.Title, input.Description, input.TimeLimit, input.ShowAnswers, input.MaxAttempts, input.AttemptCooldown, input.ScorePolicy,
+		input.ShuffleQuestions, input.ShuffleAnswers, input.PoolSize, input.PoolByTag, quizID, input.Visibility, input.AccessCode, input.OpensAt, input.ClosesAt,
+		input.ClearOpensAt, input.ClearClosesAt).StructScan(&quiz)
 
 	if err != nil {
 		span.RecordError(err)
@@ -166,6 +478,264 @@ func (repo *Repository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
+func (repo *Repository) Publish(ctx context.Context, quizID int, questions []models.QuestionWithAnswers) (models.Quiz, error) {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.Publish")
+	defer span.End()
+
+	snapshot, err := json.Marshal(questions)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	defer tx.Rollback()
+
+	var versionID int
+
+	err = tx.QueryRowxContext(ctx, `INSERT INTO quiz_versions (quiz_id, version, questions)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2::jsonb FROM quiz_versions WHERE quiz_id = $1
+		RETURNING id`, quizID, string(snapshot)).Scan(&versionID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	var quiz models.Quiz
+
+	err = tx.QueryRowxContext(ctx, `UPDATE quizzes SET status = 'published', version_id = $1 WHERE id = $2
+		RETURNING id, title, description, image, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy, shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at, status, version_id, created_at`,
+		versionID, quizID).StructScan(&quiz)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	return quiz, nil
+}
+
+func (repo *Repository) SetStatus(ctx context.Context, quizID int, status string) (models.Quiz, error) {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.SetStatus")
+	defer span.End()
+
+	var quiz models.Quiz
+
+	err := repo.db.QueryRowxContext(ctx, `UPDATE quizzes SET status = $1 WHERE id = $2
+		RETURNING id, title, description, image, user_id, time_limit, show_answers, max_attempts, attempt_cooldown, score_policy, shuffle_questions, shuffle_answers, pool_size, pool_by_tag, visibility, access_code, opens_at, closes_at, status, version_id, created_at`,
+		status, quizID).StructScan(&quiz)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	return quiz, nil
+}
+
+func (repo *Repository) GetVersion(ctx context.Context, versionID int) (models.QuizVersion, error) {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.GetVersion")
+	defer span.End()
+
+	var version models.QuizVersion
+	var snapshot []byte
+
+	err := repo.db.QueryRowxContext(ctx, "SELECT id, quiz_id, version, questions, created_at FROM quiz_versions WHERE id = $1", versionID).
+		Scan(&version.ID, &version.QuizID, &version.Version, &snapshot, &version.CreatedAt)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.QuizVersion{}, err
+	}
+
+	if err := json.Unmarshal(snapshot, &version.Questions); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.QuizVersion{}, err
+	}
+
+	return version, nil
+}
+
+// GetVersionFiles returns the names of the images and audio clips referenced by
+// the published versions of the quiz.
+func (repo *Repository) GetVersionFiles(ctx context.Context, quizID int) ([]string, error) {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.GetVersionFiles")
+	defer span.End()
+
+	fileNames := make([]string, 0)
+
+	err := repo.db.SelectContext(ctx, &fileNames, `SELECT DISTINCT f.name FROM quiz_versions v
+		CROSS JOIN LATERAL jsonb_array_elements(v.questions) q
+		CROSS JOIN LATERAL (
+			SELECT q->>'image' AS name
+			UNION ALL SELECT q->>'audio'
+			UNION ALL SELECT a->>'image' FROM jsonb_array_elements(
+				CASE WHEN jsonb_typeof(q->'answers') = 'array' THEN q->'answers' ELSE '[]'::jsonb END) a
+		) f
+		WHERE v.quiz_id = $1 AND COALESCE(f.name, '') <> ''`, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return fileNames, nil
+}
+
+func (repo *Repository) GetInvites(ctx context.Context, quizID int) ([]models.QuizInvite, error) {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.GetInvites")
+	defer span.End()
+
+	invites := make([]models.QuizInvite, 0)
+
+	err := repo.db.SelectContext(ctx, &invites, `SELECT i.quiz_id, i.user_id, u.username, u.email, i.created_at
+		FROM quiz_invites i
+		INNER JOIN users u ON u.id = i.user_id
+		WHERE i.quiz_id = $1
+		ORDER BY i.created_at`, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (repo *Repository) AddInvite(ctx context.Context, quizID int, email string) error {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.AddInvite")
+	defer span.End()
+
+	var userID int
+
+	err := repo.db.QueryRowxContext(ctx, "SELECT id FROM users WHERE email = $1", email).Scan(&userID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	_, err = repo.db.ExecContext(ctx, `INSERT INTO quiz_invites (quiz_id, user_id) VALUES ($1, $2)
+		ON CONFLICT (quiz_id, user_id) DO NOTHING`, quizID, userID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+func (repo *Repository) DeleteInvite(ctx context.Context, quizID, userID int) error {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.DeleteInvite")
+	defer span.End()
+
+	res, err := repo.db.ExecContext(ctx, "DELETE FROM quiz_invites WHERE quiz_id = $1 AND user_id = $2", quizID, userID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	count, err := res.RowsAffected()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repo *Repository) HasInvite(ctx context.Context, quizID, userID int) (bool, error) {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.HasInvite")
+	defer span.End()
+
+	var exists bool
+
+	err := repo.db.QueryRowxContext(ctx, "SELECT EXISTS(SELECT 1 FROM quiz_invites WHERE quiz_id = $1 AND user_id = $2)",
+		quizID, userID).Scan(&exists)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// GetOwnedFiles returns the names among fileNames that are used by quizzes, questions
+// or answers of the user.
+func (repo *Repository) GetOwnedFiles(ctx context.Context, userID int, fileNames []string) ([]string, error) {
+	ctx, span := repo.tracer.Start(ctx, "quizRepo.GetOwnedFiles")
+	defer span.End()
+
+	owned := make([]string, 0)
+
+	err := repo.db.SelectContext(ctx, &owned, `SELECT DISTINCT f.name FROM (
+			SELECT z.image AS name FROM quizzes z WHERE z.user_id = $1
+			UNION ALL SELECT q.image FROM questions q JOIN quizzes z ON z.id = q.quiz_id WHERE z.user_id = $1
+			UNION ALL SELECT q.audio FROM questions q JOIN quizzes z ON z.id = q.quiz_id WHERE z.user_id = $1
+			UNION ALL SELECT a.image FROM answers a JOIN questions q ON q.id = a.question_id JOIN quizzes z ON z.id = q.quiz_id WHERE z.user_id = $1
+		) f WHERE f.name = ANY($2)`, userID, fileNames)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return owned, nil
+}
+
 func (repo *Repository) UploadImage(ctx context.Context, id int, filename string) error {
 	ctx, span := repo.tracer.Start(ctx, "quizRepo.UploadImage")
 	defer span.End()
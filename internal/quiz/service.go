@@ -4,14 +4,24 @@ import (
 	"context"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	"github.com/blazee5/quizmaster-backend/internal/models"
+	"io"
 	"mime/multipart"
 )
 
 type Service interface {
 	Create(ctx context.Context, userID int, input domain.Quiz) (int, error)
-	GetAll(ctx context.Context, title, sortBy, sortDir string, page, size int) (models.QuizList, error)
-	GetByID(ctx context.Context, id int) (models.Quiz, error)
-	Update(ctx context.Context, userID, quizID int, input domain.Quiz) error
+	CreateTree(ctx context.Context, userID int, input domain.QuizTree) (int, error)
+	Clone(ctx context.Context, userID, quizID int) (int, error)
+	ReplaceTree(ctx context.Context, userID, quizID int, input domain.QuizTree) error
+	Export(ctx context.Context, userID, quizID int, format string, w io.Writer) error
+	GetAll(ctx context.Context, title, sortBy, sortDir, availability string, page, size int) (models.QuizList, error)
+	GetByID(ctx context.Context, id, userID int, accessCode string) (models.Quiz, error)
+	GetInvites(ctx context.Context, userID, quizID int) ([]models.QuizInvite, error)
+	AddInvite(ctx context.Context, userID, quizID int, input domain.QuizInvite) error
+	DeleteInvite(ctx context.Context, userID, quizID, inviteeID int) error
+	Publish(ctx context.Context, userID, quizID int) (int, error)
+	Archive(ctx context.Context, userID, quizID int) error
+	Update(ctx context.Context, userID, quizID int, input domain.UpdateQuiz) error
 	Delete(ctx context.Context, userID, quizID int) error
 	UploadImage(ctx context.Context, userID, quizID int, fileHeader *multipart.FileHeader) error
 	DeleteImage(ctx context.Context, userID, quizID int) error
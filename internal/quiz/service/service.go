@@ -2,18 +2,26 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/blazee5/quizmaster-backend/internal/question"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
 	quizRepo "github.com/blazee5/quizmaster-backend/internal/quiz"
+	"github.com/blazee5/quizmaster-backend/internal/quiz/format"
+	"github.com/blazee5/quizmaster-backend/internal/quiz/media"
+	"github.com/blazee5/quizmaster-backend/internal/quiz/visibility"
 	"github.com/blazee5/quizmaster-backend/internal/user"
 	"github.com/blazee5/quizmaster-backend/lib/files"
 	"github.com/blazee5/quizmaster-backend/lib/http_errors"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"io"
 	"mime/multipart"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Service struct {
@@ -23,14 +31,15 @@ type Service struct {
 	userRedisRepo user.RedisRepository
 	elasticRepo   quizRepo.ElasticRepository
 	awsRepo       quizRepo.AWSRepository
+	questionRepo  question.Repository
 	tracer        trace.Tracer
 }
 
-func NewService(log *zap.SugaredLogger, repo quizRepo.Repository, quizRedisRepo quizRepo.RedisRepository, userRedisRepo user.RedisRepository, elasticRepo quizRepo.ElasticRepository, awsRepo quizRepo.AWSRepository, tracer trace.Tracer) *Service {
-	return &Service{log: log, repo: repo, quizRedisRepo: quizRedisRepo, userRedisRepo: userRedisRepo, elasticRepo: elasticRepo, awsRepo: awsRepo, tracer: tracer}
+func NewService(log *zap.SugaredLogger, repo quizRepo.Repository, quizRedisRepo quizRepo.RedisRepository, userRedisRepo user.RedisRepository, elasticRepo quizRepo.ElasticRepository, awsRepo quizRepo.AWSRepository, questionRepo question.Repository, tracer trace.Tracer) *Service {
+	return &Service{log: log, repo: repo, quizRedisRepo: quizRedisRepo, userRedisRepo: userRedisRepo, elasticRepo: elasticRepo, awsRepo: awsRepo, questionRepo: questionRepo, tracer: tracer}
 }
 
-func (s *Service) GetAll(ctx context.Context, title, sortBy, sortDir string, page, size int) (models.QuizList, error) {
+func (s *Service) GetAll(ctx context.Context, title, sortBy, sortDir, availability string, page, size int) (models.QuizList, error) {
 	ctx, span := s.tracer.Start(ctx, "quizService.GetAll")
 	defer span.End()
 
@@ -51,7 +60,7 @@ func (s *Service) GetAll(ctx context.Context, title, sortBy, sortDir string, pag
 			sortDir = "ASC"
 		}
 
-		quizzes, err = s.repo.GetAll(ctx, sortBy, sortDir, page, size)
+		quizzes, err = s.repo.GetAll(ctx, sortBy, sortDir, availability, page, size)
 	} else {
 		if sortDir == "desc" {
 			sortDir = "desc"
@@ -59,7 +68,7 @@ func (s *Service) GetAll(ctx context.Context, title, sortBy, sortDir string, pag
 			sortDir = "asc"
 		}
 
-		quizzes, err = s.elasticRepo.SearchIndex(ctx, strings.ToLower(title), sortBy, sortDir, page, size)
+		quizzes, err = s.elasticRepo.SearchIndex(ctx, strings.ToLower(title), sortBy, sortDir, availability, page, size)
 	}
 
 	if err != nil {
@@ -69,10 +78,31 @@ func (s *Service) GetAll(ctx context.Context, title, sortBy, sortDir string, pag
 	return quizzes, nil
 }
 
-func (s *Service) GetByID(ctx context.Context, id int) (models.Quiz, error) {
+func (s *Service) GetByID(ctx context.Context, id, userID int, accessCode string) (models.Quiz, error) {
 	ctx, span := s.tracer.Start(ctx, "quizService.GetByID")
 	defer span.End()
 
+	quiz, err := s.getByID(ctx, id)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Quiz{}, err
+	}
+
+	if err := visibility.Check(ctx, s.repo, quiz, userID, accessCode); err != nil {
+		return models.Quiz{}, err
+	}
+
+	if quiz.UserID != userID {
+		quiz.AccessCode = ""
+	}
+
+	return quiz, nil
+}
+
+func (s *Service) getByID(ctx context.Context, id int) (models.Quiz, error) {
 	cachedQuiz, err := s.quizRedisRepo.GetByIDCtx(ctx, strconv.Itoa(id))
 
 	if err != nil {
@@ -86,9 +116,6 @@ func (s *Service) GetByID(ctx context.Context, id int) (models.Quiz, error) {
 	quiz, err := s.repo.GetByID(ctx, id)
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-
 		return models.Quiz{}, err
 	}
 
@@ -103,6 +130,10 @@ func (s *Service) Create(ctx context.Context, userID int, input domain.Quiz) (in
 	ctx, span := s.tracer.Start(ctx, "quizService.Create")
 	defer span.End()
 
+	if err := validateSchedule(input.OpensAt, input.ClosesAt); err != nil {
+		return 0, err
+	}
+
 	quiz, err := s.repo.Create(ctx, userID, input)
 
 	if err != nil {
@@ -129,7 +160,668 @@ func (s *Service) Create(ctx context.Context, userID int, input domain.Quiz) (in
 	return quiz.ID, nil
 }
 
-func (s *Service) Update(ctx context.Context, userID, quizID int, input domain.Quiz) error {
+func (s *Service) CreateTree(ctx context.Context, userID int, input domain.QuizTree) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "quizService.CreateTree")
+	defer span.End()
+
+	if err := validateTree(input); err != nil {
+		return 0, err
+	}
+
+	return s.createTree(ctx, userID, userID, input)
+}
+
+func (s *Service) Clone(ctx context.Context, userID, quizID int) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "quizService.Clone")
+	defer span.End()
+
+	quiz, err := s.repo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	if err := visibility.Check(ctx, s.repo, quiz, userID, ""); err != nil {
+		return 0, err
+	}
+
+	questions, err := s.questionRepo.GetQuestionsAuthor(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	return s.createTree(ctx, userID, quiz.UserID, newQuizTree(quiz, questions))
+}
+
+// createTree stores the tree as a new draft quiz of userID with its own copies of all
+// images and audio clips. The referenced files must belong to fileOwnerID.
+func (s *Service) createTree(ctx context.Context, userID, fileOwnerID int, input domain.QuizTree) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "quizService.createTree")
+	defer span.End()
+
+	if err := s.checkFiles(ctx, fileOwnerID, treeFileRefs(&input)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	copied, err := s.copyFiles(ctx, treeFileRefs(&input))
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	quiz, err := s.repo.CreateTree(ctx, userID, input)
+
+	if err != nil {
+		s.deleteFiles(ctx, copied)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	if err := s.userRedisRepo.DeleteUserCtx(ctx, strconv.Itoa(userID)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	if err = s.elasticRepo.CreateIndex(ctx, quiz); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	return quiz.ID, nil
+}
+
+func (s *Service) ReplaceTree(ctx context.Context, userID, quizID int, input domain.QuizTree) error {
+	ctx, span := s.tracer.Start(ctx, "quizService.ReplaceTree")
+	defer span.End()
+
+	quiz, err := s.repo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if quiz.UserID != userID {
+		return http_errors.ErrPermissionDenied
+	}
+
+	if err := validateTree(input); err != nil {
+		return err
+	}
+
+	questions, err := s.questionRepo.GetQuestionsAuthor(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	existing := make(map[int]models.QuestionWithAnswers, len(questions))
+	removed := make(map[int]bool, len(questions))
+
+	for _, question := range questions {
+		existing[question.ID] = question
+		removed[question.ID] = true
+	}
+
+	// Images of answers dropped from the questions that are kept.
+	orphaned := make([]string, 0)
+
+	for _, question := range input.Questions {
+		if question.ID == 0 {
+			continue
+		}
+
+		current, ok := existing[question.ID]
+
+		if !ok || !removed[question.ID] {
+			return fmt.Errorf("%w: question %d is unknown or duplicated", http_errors.ErrWrongArgument, question.ID)
+		}
+
+		answerIDs := make(map[int]bool, len(current.Answers))
+
+		for _, answer := range current.Answers {
+			answerIDs[answer.ID] = true
+		}
+
+		kept := make(map[int]bool, len(question.Answers))
+
+		for _, answer := range question.Answers {
+			if answer.ID != 0 && !answerIDs[answer.ID] {
+				return fmt.Errorf("%w: answer %d does not belong to question %d", http_errors.ErrWrongArgument, answer.ID, question.ID)
+			}
+
+			kept[answer.ID] = true
+		}
+
+		for _, answer := range current.Answers {
+			if !kept[answer.ID] && answer.Image != "" {
+				orphaned = append(orphaned, answer.Image)
+			}
+		}
+
+		delete(removed, question.ID)
+	}
+
+	// New questions and answers get their own copies of the files they reference,
+	// the files of existing ones are kept as they are.
+	added := make([]*string, 0)
+
+	for i := range input.Questions {
+		question := &input.Questions[i]
+
+		if question.ID == 0 {
+			added = append(added, questionFileRefs(question)...)
+
+			continue
+		}
+
+		for j := range question.Answers {
+			if question.Answers[j].ID == 0 {
+				added = append(added, &question.Answers[j].Image)
+			}
+		}
+	}
+
+	if err := s.checkFiles(ctx, userID, added); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	copied, err := s.copyFiles(ctx, added)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	quiz, err = s.repo.ReplaceTree(ctx, quizID, input)
+
+	if err != nil {
+		s.deleteFiles(ctx, copied)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	for id := range removed {
+		question := newQuestionTree(existing[id])
+		orphaned = append(orphaned, fileNames(questionFileRefs(&question))...)
+	}
+
+	media.DeleteUnused(ctx, s.log, s.repo, s.awsRepo, quizID, orphaned)
+
+	if err = s.elasticRepo.UpdateIndex(ctx, quizID, quiz); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if err = s.quizRedisRepo.DeleteQuizCtx(ctx, strconv.Itoa(quizID)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) Export(ctx context.Context, userID, quizID int, exportFormat string, w io.Writer) error {
+	ctx, span := s.tracer.Start(ctx, "quizService.Export")
+	defer span.End()
+
+	quiz, err := s.repo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if quiz.UserID != userID {
+		return http_errors.ErrPermissionDenied
+	}
+
+	questions, err := s.questionRepo.GetQuestionsAuthor(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return format.Encode(w, exportFormat, newQuizTree(quiz, questions))
+}
+
+// copyFile duplicates a file reference under a new object name, so the new
+// quiz never shares objects with the quiz it was exported or cloned from.
+func (s *Service) copyFile(ctx context.Context, fileName string) (string, error) {
+	newFileName, err := files.GenerateFileName(fileName)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.awsRepo.CopyFile(ctx, fileName, newFileName); err != nil {
+		return "", fmt.Errorf("copy file %s: %w", fileName, err)
+	}
+
+	return newFileName, nil
+}
+
+// copyFiles points the references to copies of their objects. If a copy fails,
+// the copies made so far are deleted and the error is returned.
+func (s *Service) copyFiles(ctx context.Context, refs []*string) ([]string, error) {
+	copied := make([]string, 0, len(refs))
+
+	for _, ref := range refs {
+		if *ref == "" {
+			continue
+		}
+
+		fileName, err := s.copyFile(ctx, *ref)
+
+		if err != nil {
+			s.deleteFiles(ctx, copied)
+
+			return nil, err
+		}
+
+		*ref = fileName
+		copied = append(copied, fileName)
+	}
+
+	return copied, nil
+}
+
+// checkFiles makes sure the references only name objects used by quizzes of the user,
+// so a tree cannot copy media out of quizzes of other users.
+func (s *Service) checkFiles(ctx context.Context, userID int, refs []*string) error {
+	names := fileNames(refs)
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	owned, err := s.repo.GetOwnedFiles(ctx, userID, names)
+
+	if err != nil {
+		return err
+	}
+
+	ownedNames := make(map[string]bool, len(owned))
+
+	for _, name := range owned {
+		ownedNames[name] = true
+	}
+
+	for _, name := range names {
+		if !ownedNames[name] {
+			return fmt.Errorf("%w: file %s is not found", http_errors.ErrWrongArgument, name)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteFiles(ctx context.Context, fileNames []string) {
+	for _, fileName := range fileNames {
+		if fileName == "" {
+			continue
+		}
+
+		if err := s.awsRepo.DeleteFile(ctx, fileName); err != nil {
+			s.log.Infof("error while delete file %s: %v", fileName, err)
+		}
+	}
+}
+
+// treeFileRefs returns pointers to every file reference of the tree.
+func treeFileRefs(input *domain.QuizTree) []*string {
+	refs := []*string{&input.Image}
+
+	for i := range input.Questions {
+		refs = append(refs, questionFileRefs(&input.Questions[i])...)
+	}
+
+	return refs
+}
+
+func questionFileRefs(question *domain.QuestionTree) []*string {
+	refs := []*string{&question.Image, &question.Audio}
+
+	for i := range question.Answers {
+		refs = append(refs, &question.Answers[i].Image)
+	}
+
+	return refs
+}
+
+// fileNames returns the names the references point to, skipping empty ones.
+func fileNames(refs []*string) []string {
+	names := make([]string, 0, len(refs))
+
+	for _, ref := range refs {
+		if *ref != "" {
+			names = append(names, *ref)
+		}
+	}
+
+	return names
+}
+
+func validateSchedule(opensAt, closesAt *time.Time) error {
+	if opensAt != nil && closesAt != nil && !closesAt.After(*opensAt) {
+		return fmt.Errorf("%w: closes_at must be after opens_at", http_errors.ErrWrongArgument)
+	}
+
+	return nil
+}
+
+func validateTree(input domain.QuizTree) error {
+	if err := validateSchedule(input.OpensAt, input.ClosesAt); err != nil {
+		return err
+	}
+
+	for i, question := range input.Questions {
+		if strings.TrimSpace(question.Title) == "" {
+			return fmt.Errorf("%w: question %d has no title", http_errors.ErrWrongArgument, i+1)
+		}
+
+		answers := make([]domain.Answer, 0, len(question.Answers))
+
+		for _, answer := range question.Answers {
+			if strings.TrimSpace(answer.Text) == "" {
+				return fmt.Errorf("%w: question %d has an empty answer", http_errors.ErrWrongArgument, i+1)
+			}
+
+			answers = append(answers, answer.Answer)
+		}
+
+		if err := types.ValidateScoring(question.Type, question.Scoring); err != nil {
+			return fmt.Errorf("%w: question %d: %v", http_errors.ErrWrongArgument, i+1, err)
+		}
+
+		if err := types.ValidateKey(question.Type, question.MatchMode, answers); err != nil {
+			return fmt.Errorf("%w: question %d %v", http_errors.ErrWrongArgument, i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func newQuizTree(quiz models.Quiz, questions []models.QuestionWithAnswers) domain.QuizTree {
+	tree := domain.QuizTree{
+		Quiz: domain.Quiz{
+			Title:            quiz.Title,
+			Description:      quiz.Description,
+			TimeLimit:        quiz.TimeLimit,
+			ShowAnswers:      quiz.ShowAnswers,
+			MaxAttempts:      quiz.MaxAttempts,
+			AttemptCooldown:  quiz.AttemptCooldown,
+			ScorePolicy:      quiz.ScorePolicy,
+			ShuffleQuestions: quiz.ShuffleQuestions,
+			ShuffleAnswers:   quiz.ShuffleAnswers,
+			PoolSize:         quiz.PoolSize,
+			PoolByTag:        quiz.PoolByTag,
+			Visibility:       quiz.Visibility,
+			AccessCode:       quiz.AccessCode,
+			OpensAt:          quiz.OpensAt,
+			ClosesAt:         quiz.ClosesAt,
+		},
+		Image:     quiz.Image,
+		Questions: make([]domain.QuestionTree, 0, len(questions)),
+	}
+
+	for _, question := range questions {
+		tree.Questions = append(tree.Questions, newQuestionTree(question))
+	}
+
+	return tree
+}
+
+func newQuestionTree(question models.QuestionWithAnswers) domain.QuestionTree {
+	item := domain.QuestionTree{
+		ID: question.ID,
+		Question: domain.Question{
+			Title:         question.Title,
+			Type:          question.Type,
+			OrderID:       question.OrderID,
+			Scoring:       question.Scoring,
+			Points:        &question.Points,
+			Tag:           question.Tag,
+			MatchMode:     question.MatchMode,
+			IgnoreAccents: question.IgnoreAccents,
+			TypoTolerance: question.TypoTolerance,
+			Explanation:   question.Explanation,
+		},
+		Image:   question.Image,
+		Audio:   question.Audio,
+		Answers: make([]domain.AnswerTree, 0, len(question.Answers)),
+	}
+
+	for _, answer := range question.Answers {
+		item.Answers = append(item.Answers, domain.AnswerTree{
+			ID: answer.ID,
+			Answer: domain.Answer{
+				Text:      answer.Text,
+				IsCorrect: answer.IsCorrect,
+				OrderID:   answer.OrderID,
+				MatchText: answer.MatchText,
+			},
+			Image: answer.Image,
+		})
+	}
+
+	return item
+}
+
+func (s *Service) Publish(ctx context.Context, userID, quizID int) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "quizService.Publish")
+	defer span.End()
+
+	quiz, err := s.repo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	if quiz.UserID != userID {
+		return 0, http_errors.ErrPermissionDenied
+	}
+
+	questions, err := s.questionRepo.GetQuestionsAuthor(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	if len(questions) == 0 {
+		return 0, http_errors.ErrWrongArgument
+	}
+
+	if err := validateTree(newQuizTree(quiz, questions)); err != nil {
+		return 0, err
+	}
+
+	quiz, err = s.repo.Publish(ctx, quizID, questions)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	if err := s.syncStatus(ctx, quiz); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	return *quiz.VersionID, nil
+}
+
+func (s *Service) Archive(ctx context.Context, userID, quizID int) error {
+	ctx, span := s.tracer.Start(ctx, "quizService.Archive")
+	defer span.End()
+
+	quiz, err := s.repo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if quiz.UserID != userID {
+		return http_errors.ErrPermissionDenied
+	}
+
+	quiz, err = s.repo.SetStatus(ctx, quizID, "archived")
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if err := s.syncStatus(ctx, quiz); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) syncStatus(ctx context.Context, quiz models.Quiz) error {
+	if err := s.elasticRepo.UpdateIndex(ctx, quiz.ID, quiz); err != nil {
+		return err
+	}
+
+	return s.quizRedisRepo.DeleteQuizCtx(ctx, strconv.Itoa(quiz.ID))
+}
+
+func (s *Service) GetInvites(ctx context.Context, userID, quizID int) ([]models.QuizInvite, error) {
+	ctx, span := s.tracer.Start(ctx, "quizService.GetInvites")
+	defer span.End()
+
+	if err := s.checkOwner(ctx, userID, quizID); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.repo.GetInvites(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (s *Service) AddInvite(ctx context.Context, userID, quizID int, input domain.QuizInvite) error {
+	ctx, span := s.tracer.Start(ctx, "quizService.AddInvite")
+	defer span.End()
+
+	if err := s.checkOwner(ctx, userID, quizID); err != nil {
+		return err
+	}
+
+	err := s.repo.AddInvite(ctx, quizID, input.Email)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteInvite(ctx context.Context, userID, quizID, inviteeID int) error {
+	ctx, span := s.tracer.Start(ctx, "quizService.DeleteInvite")
+	defer span.End()
+
+	if err := s.checkOwner(ctx, userID, quizID); err != nil {
+		return err
+	}
+
+	err := s.repo.DeleteInvite(ctx, quizID, inviteeID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) checkOwner(ctx context.Context, userID, quizID int) error {
+	quiz, err := s.repo.GetByID(ctx, quizID)
+
+	if err != nil {
+		return err
+	}
+
+	if quiz.UserID != userID {
+		return http_errors.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+func (s *Service) Update(ctx context.Context, userID, quizID int, input domain.UpdateQuiz) error {
 	ctx, span := s.tracer.Start(ctx, "quizService.Update")
 	defer span.End()
 
@@ -146,6 +838,20 @@ func (s *Service) Update(ctx context.Context, userID, quizID int, input domain.Q
 		return http_errors.ErrPermissionDenied
 	}
 
+	opensAt, closesAt := quiz.OpensAt, quiz.ClosesAt
+
+	if input.OpensAt != nil || input.ClearOpensAt {
+		opensAt = input.OpensAt
+	}
+
+	if input.ClosesAt != nil || input.ClearClosesAt {
+		closesAt = input.ClosesAt
+	}
+
+	if err := validateSchedule(opensAt, closesAt); err != nil {
+		return err
+	}
+
 	quiz, err = s.repo.Update(ctx, quizID, input)
 
 	if err != nil {
@@ -189,6 +895,25 @@ func (s *Service) Delete(ctx context.Context, userID, quizID int) error {
 		return http_errors.ErrPermissionDenied
 	}
 
+	questions, err := s.questionRepo.GetQuestionsAuthor(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	// Published versions are deleted with the quiz, so the files only they kept go as well.
+	versionFiles, err := s.repo.GetVersionFiles(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
 	err = s.repo.Delete(ctx, quizID)
 
 	if err != nil {
@@ -198,6 +923,22 @@ func (s *Service) Delete(ctx context.Context, userID, quizID int) error {
 		return err
 	}
 
+	tree := newQuizTree(quiz, questions)
+	quizFiles := fileNames(treeFileRefs(&tree))
+	deleted := make(map[string]bool, len(quizFiles))
+
+	for _, fileName := range quizFiles {
+		deleted[fileName] = true
+	}
+
+	for _, fileName := range versionFiles {
+		if !deleted[fileName] {
+			quizFiles = append(quizFiles, fileName)
+		}
+	}
+
+	s.deleteFiles(ctx, quizFiles)
+
 	err = s.elasticRepo.DeleteIndex(ctx, quizID)
 
 	if err != nil {
@@ -262,11 +1003,9 @@ func (s *Service) UploadImage(ctx context.Context, userID, quizID int, fileHeade
 		return err
 	}
 
-	err = s.elasticRepo.UpdateIndex(ctx, quizID, models.Quiz{
-		Title:       quiz.Title,
-		Description: quiz.Description,
-		Image:       fileName,
-	})
+	quiz.Image = fileName
+
+	err = s.elasticRepo.UpdateIndex(ctx, quizID, quiz)
 
 	if err != nil {
 		span.RecordError(err)
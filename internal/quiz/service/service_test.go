@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	mock_question "github.com/blazee5/quizmaster-backend/internal/question/mock"
+	mock_quiz "github.com/blazee5/quizmaster-backend/internal/quiz/mock"
+	mock_user "github.com/blazee5/quizmaster-backend/internal/user/mock"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/blazee5/quizmaster-backend/lib/logger"
+	"github.com/blazee5/quizmaster-backend/lib/tracer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+type mocks struct {
+	repo         *mock_quiz.MockRepository
+	redisRepo    *mock_quiz.MockRedisRepository
+	userRedis    *mock_user.MockRedisRepository
+	elasticRepo  *mock_quiz.MockElasticRepository
+	awsRepo      *mock_quiz.MockAWSRepository
+	questionRepo *mock_question.MockRepository
+}
+
+func newTestService(t *testing.T) (*Service, mocks) {
+	ctrl := gomock.NewController(t)
+
+	m := mocks{
+		repo:         mock_quiz.NewMockRepository(ctrl),
+		redisRepo:    mock_quiz.NewMockRedisRepository(ctrl),
+		userRedis:    mock_user.NewMockRedisRepository(ctrl),
+		elasticRepo:  mock_quiz.NewMockElasticRepository(ctrl),
+		awsRepo:      mock_quiz.NewMockAWSRepository(ctrl),
+		questionRepo: mock_question.NewMockRepository(ctrl),
+	}
+
+	service := NewService(logger.NewLogger(), m.repo, m.redisRepo, m.userRedis, m.elasticRepo, m.awsRepo, m.questionRepo, tracer.InitTracer("main"))
+
+	return service, m
+}
+
+func TestService_ReplaceTreeForeignFile(t *testing.T) {
+	t.Parallel()
+
+	service, m := newTestService(t)
+
+	input := domain.QuizTree{
+		Quiz: domain.Quiz{Title: "Capitals"},
+		Questions: []domain.QuestionTree{
+			{
+				Question: domain.Question{Title: "Capital of France?", Type: "choice"},
+				Image:    "foreign.png",
+				Answers: []domain.AnswerTree{
+					{Answer: domain.Answer{Text: "Paris", IsCorrect: true}},
+					{Answer: domain.Answer{Text: "Lyon"}},
+				},
+			},
+		},
+	}
+
+	m.repo.EXPECT().GetByID(gomock.Any(), 1).Return(models.Quiz{ID: 1, UserID: 1}, nil)
+	m.questionRepo.EXPECT().GetQuestionsAuthor(gomock.Any(), 1).Return([]models.QuestionWithAnswers{}, nil)
+	m.repo.EXPECT().GetOwnedFiles(gomock.Any(), 1, []string{"foreign.png"}).Return([]string{}, nil)
+
+	err := service.ReplaceTree(context.Background(), 1, 1, input)
+
+	require.ErrorIs(t, err, http_errors.ErrWrongArgument)
+}
+
+func TestService_Clone(t *testing.T) {
+	t.Parallel()
+
+	service, m := newTestService(t)
+
+	quiz := models.Quiz{ID: 1, UserID: 1, Title: "Listening", Image: "quiz.png"}
+	questions := []models.QuestionWithAnswers{
+		{
+			ID:    10,
+			Title: "What did you hear?",
+			Type:  "choice",
+			Image: "question.png",
+			Audio: "question.mp3",
+			Answers: []models.Answer{
+				{ID: 100, Text: "A cat", IsCorrect: true, Image: "cat.png"},
+				{ID: 101, Text: "A dog"},
+			},
+		},
+	}
+	originals := []string{"quiz.png", "question.png", "question.mp3", "cat.png"}
+
+	m.repo.EXPECT().GetByID(gomock.Any(), 1).Return(quiz, nil)
+	m.questionRepo.EXPECT().GetQuestionsAuthor(gomock.Any(), 1).Return(questions, nil)
+	m.repo.EXPECT().GetOwnedFiles(gomock.Any(), 1, originals).Return(originals, nil)
+
+	copies := make(map[string]string, len(originals))
+
+	m.awsRepo.EXPECT().CopyFile(gomock.Any(), gomock.Any(), gomock.Any()).Times(len(originals)).
+		DoAndReturn(func(_ context.Context, src, dst string) error {
+			copies[src] = dst
+
+			return nil
+		})
+
+	var stored domain.QuizTree
+
+	m.repo.EXPECT().CreateTree(gomock.Any(), 1, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, input domain.QuizTree) (models.Quiz, error) {
+			stored = input
+
+			return models.Quiz{ID: 2, UserID: 1}, nil
+		})
+	m.userRedis.EXPECT().DeleteUserCtx(gomock.Any(), "1").Return(nil)
+	m.elasticRepo.EXPECT().CreateIndex(gomock.Any(), gomock.Any()).Return(nil)
+
+	id, err := service.Clone(context.Background(), 1, 1)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, id)
+
+	for _, original := range originals {
+		require.NotEmpty(t, copies[original])
+		require.NotEqual(t, original, copies[original])
+	}
+
+	require.Equal(t, copies["quiz.png"], stored.Image)
+	require.Equal(t, copies["question.png"], stored.Questions[0].Image)
+	require.Equal(t, copies["question.mp3"], stored.Questions[0].Audio)
+	require.Equal(t, copies["cat.png"], stored.Questions[0].Answers[0].Image)
+	require.Empty(t, stored.Questions[0].Answers[1].Image)
+	require.Equal(t, "What did you hear?", stored.Questions[0].Title)
+	require.True(t, stored.Questions[0].Answers[0].IsCorrect)
+}
+
+func TestService_CloneVisibility(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		visibility string
+		expected   error
+	}{
+		{"public quiz", "public", nil},
+		{"unlisted quiz", "unlisted", nil},
+		{"private quiz", "private", http_errors.ErrPermissionDenied},
+		{"access code quiz", "access_code", http_errors.ErrAccessCodeRequired},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, m := newTestService(t)
+
+			quiz := models.Quiz{ID: 1, UserID: 1, Title: "Capitals", Image: "quiz.png", Visibility: tt.visibility, AccessCode: "s3cret"}
+
+			m.repo.EXPECT().GetByID(gomock.Any(), 1).Return(quiz, nil)
+
+			if tt.expected == nil {
+				m.questionRepo.EXPECT().GetQuestionsAuthor(gomock.Any(), 1).Return([]models.QuestionWithAnswers{}, nil)
+				m.repo.EXPECT().GetOwnedFiles(gomock.Any(), 1, []string{"quiz.png"}).Return([]string{"quiz.png"}, nil)
+				m.awsRepo.EXPECT().CopyFile(gomock.Any(), "quiz.png", gomock.Any()).Return(nil)
+				m.repo.EXPECT().CreateTree(gomock.Any(), 2, gomock.Any()).Return(models.Quiz{ID: 3, UserID: 2}, nil)
+				m.userRedis.EXPECT().DeleteUserCtx(gomock.Any(), "2").Return(nil)
+				m.elasticRepo.EXPECT().CreateIndex(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			id, err := service.Clone(context.Background(), 2, 1)
+
+			if tt.expected != nil {
+				require.ErrorIs(t, err, tt.expected)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 3, id)
+		})
+	}
+}
+
+func TestService_CloneCopyFailure(t *testing.T) {
+	t.Parallel()
+
+	service, m := newTestService(t)
+
+	quiz := models.Quiz{ID: 1, UserID: 1, Title: "Listening", Image: "quiz.png"}
+	questions := []models.QuestionWithAnswers{
+		{ID: 10, Title: "What did you hear?", Type: "essay", Audio: "question.mp3", Answers: []models.Answer{}},
+	}
+
+	m.repo.EXPECT().GetByID(gomock.Any(), 1).Return(quiz, nil)
+	m.questionRepo.EXPECT().GetQuestionsAuthor(gomock.Any(), 1).Return(questions, nil)
+	m.repo.EXPECT().GetOwnedFiles(gomock.Any(), 1, gomock.Any()).Return([]string{"quiz.png", "question.mp3"}, nil)
+
+	var copied string
+
+	gomock.InOrder(
+		m.awsRepo.EXPECT().CopyFile(gomock.Any(), "quiz.png", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, dst string) error {
+				copied = dst
+
+				return nil
+			}),
+		m.awsRepo.EXPECT().CopyFile(gomock.Any(), "question.mp3", gomock.Any()).Return(errors.New("storage is down")),
+	)
+	m.awsRepo.EXPECT().DeleteFile(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, fileName string) {
+			require.Equal(t, copied, fileName)
+		}).Return(nil)
+
+	_, err := service.Clone(context.Background(), 1, 1)
+
+	require.Error(t, err)
+}
+
+func TestService_UpdateSchedule(t *testing.T) {
+	t.Parallel()
+
+	opensAt := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	closesAt := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	earlier := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    domain.UpdateQuiz
+		expected error
+	}{
+		{"set both", domain.UpdateQuiz{Title: "Quiz", OpensAt: &opensAt, ClosesAt: &closesAt}, nil},
+		{"change close only", domain.UpdateQuiz{Title: "Quiz", ClosesAt: &closesAt}, nil},
+		{"close before stored open", domain.UpdateQuiz{Title: "Quiz", ClosesAt: &earlier}, http_errors.ErrWrongArgument},
+		{"clear open then close earlier", domain.UpdateQuiz{Title: "Quiz", ClosesAt: &earlier, ClearOpensAt: true}, nil},
+		{"clear both", domain.UpdateQuiz{Title: "Quiz", ClearOpensAt: true, ClearClosesAt: true}, nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, m := newTestService(t)
+
+			m.repo.EXPECT().GetByID(gomock.Any(), 1).Return(models.Quiz{ID: 1, UserID: 1, OpensAt: &opensAt}, nil)
+
+			if tt.expected == nil {
+				m.repo.EXPECT().Update(gomock.Any(), 1, tt.input).Return(models.Quiz{ID: 1, UserID: 1}, nil)
+				m.elasticRepo.EXPECT().UpdateIndex(gomock.Any(), 1, gomock.Any()).Return(nil)
+				m.redisRepo.EXPECT().DeleteQuizCtx(gomock.Any(), "1").Return(nil)
+			}
+
+			err := service.Update(context.Background(), 1, 1, tt.input)
+
+			if tt.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package visibility
+
+import (
+	"context"
+	"crypto/subtle"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/blazee5/quizmaster-backend/internal/quiz"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+)
+
+const (
+	Public     = "public"
+	Unlisted   = "unlisted"
+	Private    = "private"
+	AccessCode = "access_code"
+	Invite     = "invite"
+)
+
+// Check reports whether the user may open the quiz. Public and unlisted quizzes
+// are open to everyone who knows the id, the rest need the owner, the access code
+// or an invite. userID is 0 for anonymous requests.
+func Check(ctx context.Context, repo quiz.Repository, q models.Quiz, userID int, accessCode string) error {
+	if userID != 0 && q.UserID == userID {
+		return nil
+	}
+
+	switch q.Visibility {
+	case Private:
+		return http_errors.ErrPermissionDenied
+	case AccessCode:
+		if accessCode == "" || subtle.ConstantTimeCompare([]byte(accessCode), []byte(q.AccessCode)) != 1 {
+			return http_errors.ErrAccessCodeRequired
+		}
+	case Invite:
+		if userID == 0 {
+			return http_errors.ErrPermissionDenied
+		}
+
+		invited, err := repo.HasInvite(ctx, q.ID, userID)
+
+		if err != nil {
+			return err
+		}
+
+		if !invited {
+			return http_errors.ErrPermissionDenied
+		}
+	}
+
+	return nil
+}
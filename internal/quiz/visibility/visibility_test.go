@@ -0,0 +1,72 @@
+package visibility
+
+import (
+	"context"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	mock_quiz "github.com/blazee5/quizmaster-backend/internal/quiz/mock"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	const ownerID = 1
+
+	tests := []struct {
+		name       string
+		visibility string
+		userID     int
+		accessCode string
+		invited    *bool
+		expected   error
+	}{
+		{"owner of private quiz", Private, ownerID, "", nil, nil},
+		{"owner of access code quiz without code", AccessCode, ownerID, "", nil, nil},
+		{"owner of invite quiz", Invite, ownerID, "", nil, nil},
+		{"anonymous public", Public, 0, "", nil, nil},
+		{"anonymous unlisted", Unlisted, 0, "", nil, nil},
+		{"anonymous private", Private, 0, "", nil, http_errors.ErrPermissionDenied},
+		{"user private", Private, 2, "", nil, http_errors.ErrPermissionDenied},
+		{"right code", AccessCode, 2, "s3cret", nil, nil},
+		{"anonymous right code", AccessCode, 0, "s3cret", nil, nil},
+		{"wrong code", AccessCode, 2, "guess", nil, http_errors.ErrAccessCodeRequired},
+		{"empty code", AccessCode, 2, "", nil, http_errors.ErrAccessCodeRequired},
+		{"anonymous invite", Invite, 0, "", nil, http_errors.ErrPermissionDenied},
+		{"invited", Invite, 2, "", boolPtr(true), nil},
+		{"not invited", Invite, 2, "", boolPtr(false), http_errors.ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_quiz.NewMockRepository(gomock.NewController(t))
+			quiz := models.Quiz{ID: 10, UserID: ownerID, Visibility: tt.visibility}
+
+			if tt.visibility == AccessCode {
+				quiz.AccessCode = "s3cret"
+			}
+
+			if tt.invited != nil {
+				repo.EXPECT().HasInvite(gomock.Any(), quiz.ID, tt.userID).Return(*tt.invited, nil)
+			}
+
+			err := Check(context.Background(), repo, quiz, tt.userID, tt.accessCode)
+
+			if tt.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
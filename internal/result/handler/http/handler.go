@@ -1,16 +1,16 @@
 package http
 
 import (
-	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	"github.com/blazee5/quizmaster-backend/internal/result"
+	"github.com/blazee5/quizmaster-backend/internal/result/handler/ws"
 	"github.com/blazee5/quizmaster-backend/lib/http_errors"
 	"github.com/blazee5/quizmaster-backend/lib/response"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
-	socketio "github.com/vchitai/go-socket.io/v4"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -18,14 +18,17 @@ import (
 	"strconv"
 )
 
+// maxLeaderboardSize caps the leaderboard page size.
+const maxLeaderboardSize = 100
+
 type Handler struct {
 	log     *zap.SugaredLogger
 	service result.Service
-	ws      *socketio.Server
+	ws      *ws.Handler
 	tracer  trace.Tracer
 }
 
-func NewHandler(log *zap.SugaredLogger, service result.Service, ws *socketio.Server, tracer trace.Tracer) *Handler {
+func NewHandler(log *zap.SugaredLogger, service result.Service, ws *ws.Handler, tracer trace.Tracer) *Handler {
 	return &Handler{log: log, service: service, ws: ws, tracer: tracer}
 }
 
@@ -42,7 +45,7 @@ func (h *Handler) NewResult(c echo.Context) error {
 		})
 	}
 
-	id, err := h.service.NewResult(ctx, userID, quizID)
+	attempt, err := h.service.NewResult(ctx, userID, quizID, c.Request().Header.Get("X-Access-Code"))
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return c.JSON(http.StatusNotFound, echo.Map{
@@ -50,6 +53,48 @@ func (h *Handler) NewResult(c echo.Context) error {
 		})
 	}
 
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrAccessCodeRequired) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "access code required",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrQuizNotOpen) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "quiz is not open yet",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrQuizClosed) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "quiz is closed",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrQuizNotPublished) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "quiz is not published",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrAttemptsExceeded) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "attempts limit exceeded",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrAttemptCooldown) {
+		return c.JSON(http.StatusTooManyRequests, echo.Map{
+			"message": "attempt cooldown is not over",
+		})
+	}
+
 	if err != nil {
 		h.log.Infof("error while create result: %s", err)
 
@@ -61,9 +106,48 @@ func (h *Handler) NewResult(c echo.Context) error {
 		})
 	}
 
-	return c.JSON(http.StatusOK, echo.Map{
-		"id": id,
-	})
+	return c.JSON(http.StatusOK, attempt)
+}
+
+func (h *Handler) GetCurrentAttempt(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "result.GetCurrentAttempt")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	attempt, err := h.service.GetCurrentAttempt(ctx, userID, quizID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "attempt not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrAttemptExpired) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "time is over",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while get current attempt: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, attempt)
 }
 
 func (h *Handler) SaveResult(c echo.Context) error {
@@ -95,7 +179,7 @@ func (h *Handler) SaveResult(c echo.Context) error {
 		})
 	}
 
-	err = h.service.SaveUserAnswer(ctx, userID, quizID, input)
+	attempt, err := h.service.SaveUserAnswer(ctx, userID, quizID, input)
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return c.JSON(http.StatusNotFound, echo.Map{
@@ -109,6 +193,12 @@ func (h *Handler) SaveResult(c echo.Context) error {
 		})
 	}
 
+	if errors.Is(err, http_errors.ErrAttemptExpired) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "time is over",
+		})
+	}
+
 	if errors.Is(err, http_errors.ErrWrongArgument) {
 		return c.JSON(http.StatusBadRequest, echo.Map{
 			"message": "wrong argument",
@@ -126,7 +216,7 @@ func (h *Handler) SaveResult(c echo.Context) error {
 		})
 	}
 
-	return c.String(http.StatusOK, "OK")
+	return c.JSON(http.StatusOK, attempt)
 }
 
 func (h *Handler) SubmitResult(c echo.Context) error {
@@ -172,6 +262,18 @@ func (h *Handler) SubmitResult(c echo.Context) error {
 		})
 	}
 
+	if errors.Is(err, http_errors.ErrAttemptExpired) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "time is over",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrAlreadySubmitted) {
+		return c.JSON(http.StatusConflict, echo.Map{
+			"message": "result already submitted",
+		})
+	}
+
 	if err != nil {
 		h.log.Infof("error while submit result: %s", err)
 
@@ -183,33 +285,449 @@ func (h *Handler) SubmitResult(c echo.Context) error {
 		})
 	}
 
-	h.UpdateResults(quizID)
+	go h.ws.BroadcastResults(quizID)
 
 	return c.JSON(http.StatusOK, result)
 }
 
-func (h *Handler) UpdateResults(quizID int) interface{} {
-	ctx, span := h.tracer.Start(context.Background(), "result.UpdateResults")
+func (h *Handler) GetLeaderboard(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "result.GetLeaderboard")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	period := c.QueryParam("period")
+
+	if period != "" && period != "today" && period != "week" && period != "all" {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid period",
+		})
+	}
+
+	page, err := strconv.Atoi(c.QueryParam("page"))
+
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	size, err := strconv.Atoi(c.QueryParam("size"))
+
+	if err != nil || size < 1 {
+		size = 10
+	}
+
+	size = min(size, maxLeaderboardSize)
+
+	leaderboard, err := h.service.GetLeaderboard(ctx, userID, quizID, c.Request().Header.Get("X-Access-Code"), period, page, size)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrAccessCodeRequired) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "access code required",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while get leaderboard: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, leaderboard)
+}
+
+func (h *Handler) GetAnalytics(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "result.GetAnalytics")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	analytics, err := h.service.GetAnalytics(ctx, userID, quizID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while get quiz analytics: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, analytics)
+}
+
+func (h *Handler) ExportResults(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "result.ExportResults")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	format, ok := exportFormat(c)
+
+	if !ok {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid format",
+		})
+	}
+
+	setExportHeaders(c, quizID, format)
+
+	err = h.service.ExportResults(ctx, userID, quizID, format, c.Response())
+
+	return h.exportError(c, span, err)
+}
+
+func (h *Handler) ExportResultsAdmin(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "result.ExportResultsAdmin")
+	defer span.End()
+
+	quizID, err := strconv.Atoi(c.Param("quizID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	format, ok := exportFormat(c)
+
+	if !ok {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid format",
+		})
+	}
+
+	setExportHeaders(c, quizID, format)
+
+	err = h.service.ExportResultsAdmin(ctx, quizID, format, c.Response())
+
+	return h.exportError(c, span, err)
+}
+
+func (h *Handler) exportError(c echo.Context, span trace.Span, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if c.Response().Committed {
+		h.log.Infof("error while export results: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil
+	}
+
+	c.Response().Header().Del(echo.HeaderContentDisposition)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	h.log.Infof("error while export results: %s", err)
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	return c.JSON(http.StatusInternalServerError, echo.Map{
+		"message": "server error",
+	})
+}
+
+func exportFormat(c echo.Context) (string, bool) {
+	format := c.QueryParam("format")
+
+	if format == "" {
+		format = "csv"
+	}
+
+	return format, format == "csv" || format == "xlsx"
+}
+
+func setExportHeaders(c echo.Context, quizID int, format string) {
+	contentType := "text/csv"
+
+	if format == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"quiz-%d-results.%s\"", quizID, format))
+}
+
+func (h *Handler) GetResult(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "result.GetResult")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	resultID, err := strconv.Atoi(c.Param("resultID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid result id",
+		})
+	}
+
+	review, err := h.service.GetResult(ctx, userID, quizID, resultID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "result not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while get result: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+func (h *Handler) GetGradingQueue(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "result.GetGradingQueue")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	items, err := h.service.GetGradingQueue(ctx, userID, quizID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while get grading queue: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, items)
+}
+
+func (h *Handler) GradeAnswer(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "result.GradeAnswer")
+	defer span.End()
+
+	var input domain.GradeAnswer
+
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	answerID, err := strconv.Atoi(c.Param("answerID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid answer id",
+		})
+	}
+
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "bad request",
+		})
+	}
+
+	if err := c.Validate(&input); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": response.ValidationError(validateErr),
+		})
+	}
+
+	err = h.service.GradeAnswer(ctx, userID, quizID, answerID, input)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "answer not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while grade answer: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.String(http.StatusOK, "OK")
+}
+
+func (h *Handler) FinalizeResult(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "result.FinalizeResult")
 	defer span.End()
 
-	id := strconv.Itoa(quizID)
+	userID := c.Get("userID").(int)
+	quizID, err := strconv.Atoi(c.Param("id"))
 
-	results, err := h.service.GetResultsByQuizID(ctx, quizID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid quiz id",
+		})
+	}
+
+	resultID, err := strconv.Atoi(c.Param("resultID"))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "invalid result id",
+		})
+	}
+
+	result, err := h.service.FinalizeResult(ctx, userID, quizID, resultID)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return "quiz not found"
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "result not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": err.Error(),
+		})
 	}
 
 	if err != nil {
-		h.log.Infof("error while get quiz results: %s", err)
+		h.log.Infof("error while finalize result: %s", err)
 
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return "server error"
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
 	}
 
-	h.ws.BroadcastToRoom("/results", "quiz:"+id, "message", results)
+	go h.ws.BroadcastResults(quizID)
 
-	return nil
+	return c.JSON(http.StatusOK, result)
 }
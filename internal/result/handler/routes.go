@@ -1,11 +1,13 @@
 package handler
 
 import (
-	answerRepo "github.com/blazee5/quizmaster-backend/internal/answer/repository"
+	"context"
 	"github.com/blazee5/quizmaster-backend/internal/middleware"
 	questionRepo "github.com/blazee5/quizmaster-backend/internal/question/repository"
 	quizRepo "github.com/blazee5/quizmaster-backend/internal/quiz/repository"
+	"github.com/blazee5/quizmaster-backend/internal/rabbitmq"
 	"github.com/blazee5/quizmaster-backend/internal/result/handler/http"
+	"github.com/blazee5/quizmaster-backend/internal/result/handler/worker"
 	wsHandler "github.com/blazee5/quizmaster-backend/internal/result/handler/ws"
 	resultRepo "github.com/blazee5/quizmaster-backend/internal/result/repository"
 	resultService "github.com/blazee5/quizmaster-backend/internal/result/service"
@@ -14,20 +16,52 @@ import (
 	socketio "github.com/vchitai/go-socket.io/v4"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"time"
 )
 
-func InitResultRoutes(resultGroup *echo.Group, log *zap.SugaredLogger, db *sqlx.DB, ws *socketio.Server, tracer trace.Tracer) {
+const expiredResultsInterval = 10 * time.Second
+
+func InitResultRoutes(resultGroup *echo.Group, log *zap.SugaredLogger, db *sqlx.DB, ws *socketio.Server, producer rabbitmq.QueueProducer, tracer trace.Tracer) {
 	repos := resultRepo.NewRepository(db, tracer)
 	quizRepos := quizRepo.NewRepository(db, tracer)
 	questionRepos := questionRepo.NewRepository(db, tracer)
-	answerRepos := answerRepo.NewRepository(db, tracer)
-	services := resultService.NewService(log, repos, quizRepos, questionRepos, answerRepos, tracer)
-	handlers := http.NewHandler(log, services, ws, tracer)
+	services := resultService.NewService(log, repos, quizRepos, questionRepos, producer, tracer)
 	wsHandlers := wsHandler.NewHandler(log, services, ws, tracer)
+	handlers := http.NewHandler(log, services, wsHandlers, tracer)
 
 	resultGroup.POST("/:id/start", handlers.NewResult, middleware.AuthMiddleware)
+	resultGroup.GET("/:id/attempt/current", handlers.GetCurrentAttempt, middleware.AuthMiddleware)
 	resultGroup.POST("/:id/save", handlers.SaveResult, middleware.AuthMiddleware)
 	resultGroup.POST("/:id/submit", handlers.SubmitResult, middleware.AuthMiddleware)
+	resultGroup.GET("/:id/leaderboard", handlers.GetLeaderboard, middleware.AuthMiddleware)
+	resultGroup.GET("/:id/analytics", handlers.GetAnalytics, middleware.AuthMiddleware)
+	resultGroup.GET("/:id/results/export", handlers.ExportResults, middleware.AuthMiddleware)
+	resultGroup.GET("/:id/results/:resultID", handlers.GetResult, middleware.AuthMiddleware)
+	resultGroup.POST("/:id/results/:resultID/finalize", handlers.FinalizeResult, middleware.AuthMiddleware)
+	resultGroup.GET("/:id/grading", handlers.GetGradingQueue, middleware.AuthMiddleware)
+	resultGroup.PUT("/:id/grading/:answerID", handlers.GradeAnswer, middleware.AuthMiddleware)
 
 	ws.OnEvent("/results", "message", wsHandlers.GetResults)
 }
+
+func InitAdminResultRoutes(adminQuizGroup *echo.Group, log *zap.SugaredLogger, db *sqlx.DB, ws *socketio.Server, producer rabbitmq.QueueProducer, tracer trace.Tracer) {
+	repos := resultRepo.NewRepository(db, tracer)
+	quizRepos := quizRepo.NewRepository(db, tracer)
+	questionRepos := questionRepo.NewRepository(db, tracer)
+	services := resultService.NewService(log, repos, quizRepos, questionRepos, producer, tracer)
+	wsHandlers := wsHandler.NewHandler(log, services, ws, tracer)
+	handlers := http.NewHandler(log, services, wsHandlers, tracer)
+
+	adminQuizGroup.GET("/:quizID/results/export", handlers.ExportResultsAdmin)
+}
+
+func InitResultWorker(ctx context.Context, log *zap.SugaredLogger, db *sqlx.DB, ws *socketio.Server, producer rabbitmq.QueueProducer, tracer trace.Tracer) {
+	repos := resultRepo.NewRepository(db, tracer)
+	quizRepos := quizRepo.NewRepository(db, tracer)
+	questionRepos := questionRepo.NewRepository(db, tracer)
+	services := resultService.NewService(log, repos, quizRepos, questionRepos, producer, tracer)
+	wsHandlers := wsHandler.NewHandler(log, services, ws, tracer)
+	resultWorker := worker.NewWorker(log, services, wsHandlers, tracer)
+
+	resultWorker.Run(ctx, expiredResultsInterval)
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"github.com/blazee5/quizmaster-backend/internal/result"
+	"github.com/blazee5/quizmaster-backend/internal/result/handler/ws"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"time"
+)
+
+type Worker struct {
+	log     *zap.SugaredLogger
+	service result.Service
+	ws      *ws.Handler
+	tracer  trace.Tracer
+}
+
+func NewWorker(log *zap.SugaredLogger, service result.Service, ws *ws.Handler, tracer trace.Tracer) *Worker {
+	return &Worker{log: log, service: service, ws: ws, tracer: tracer}
+}
+
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.SubmitExpiredResults(ctx)
+		}
+	}
+}
+
+func (w *Worker) SubmitExpiredResults(ctx context.Context) {
+	ctx, span := w.tracer.Start(ctx, "resultWorker.SubmitExpiredResults")
+	defer span.End()
+
+	results, err := w.service.SubmitExpiredResults(ctx)
+
+	if err != nil {
+		w.log.Infof("error while submit expired results: %v", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return
+	}
+
+	if len(results) > 0 {
+		w.log.Infof("auto-submitted %d expired results", len(results))
+	}
+
+	quizzes := make(map[int]bool)
+
+	for _, result := range results {
+		if !quizzes[result.QuizID] {
+			quizzes[result.QuizID] = true
+			w.ws.BroadcastResults(result.QuizID)
+		}
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/blazee5/quizmaster-backend/internal/result"
+	"github.com/blazee5/quizmaster-backend/lib/auth"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
 	socketio "github.com/vchitai/go-socket.io/v4"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"net/http"
 	"strconv"
 )
 
@@ -26,25 +30,69 @@ func (h *Handler) GetResults(conn socketio.Conn, quizID string) interface{} {
 	ctx, span := h.tracer.Start(context.Background(), "resultWs.GetResults")
 	defer span.End()
 
-	conn.Join("quiz:" + quizID)
-
 	id, err := strconv.Atoi(quizID)
 
 	if err != nil {
 		return "invalid quizID"
 	}
 
-	results, err := h.service.GetResultsByQuizID(ctx, id)
+	results, err := h.service.GetResultsByQuizID(ctx, connUserID(conn), id, conn.RemoteHeader().Get("X-Access-Code"))
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return "quiz not found"
 	}
 
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return "permission denied"
+	}
+
+	if errors.Is(err, http_errors.ErrAccessCodeRequired) {
+		return "access code required"
+	}
+
 	if err != nil {
 		h.log.Infof("error while get quiz results: %s", err)
 		return "server error"
 	}
 
+	conn.Join("quiz:" + quizID)
+
 	conn.Emit("message", results)
 	return results
 }
+
+func (h *Handler) BroadcastResults(quizID int) {
+	ctx, span := h.tracer.Start(context.Background(), "resultWs.BroadcastResults")
+	defer span.End()
+
+	results, err := h.service.GetBroadcastResults(ctx, quizID)
+
+	if err != nil {
+		h.log.Infof("error while broadcast quiz results: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return
+	}
+
+	h.ws.BroadcastToRoom("/results", "quiz:"+strconv.Itoa(quizID), "message", results)
+}
+
+// connUserID reads the user from the token cookie sent with the handshake, 0 for
+// anonymous connections.
+func connUserID(conn socketio.Conn) int {
+	token, err := (&http.Request{Header: conn.RemoteHeader()}).Cookie("token")
+
+	if err != nil || token.Value == "" {
+		return 0
+	}
+
+	userID, _, err := auth.ParseToken(token.Value)
+
+	if err != nil {
+		return 0
+	}
+
+	return userID
+}
@@ -0,0 +1,396 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/result/pg_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/result/pg_repository.go -destination internal/result/mock/pg_repository_mock.go
+//
+// Package mock_result is a generated GoMock package.
+package mock_result
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/blazee5/quizmaster-backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAttempts mocks base method.
+func (m *MockRepository) CountAttempts(ctx context.Context, userID, quizID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAttempts", ctx, userID, quizID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAttempts indicates an expected call of CountAttempts.
+func (mr *MockRepositoryMockRecorder) CountAttempts(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAttempts", reflect.TypeOf((*MockRepository)(nil).CountAttempts), ctx, userID, quizID)
+}
+
+// CountUngraded mocks base method.
+func (m *MockRepository) CountUngraded(ctx context.Context, resultID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUngraded", ctx, resultID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUngraded indicates an expected call of CountUngraded.
+func (mr *MockRepositoryMockRecorder) CountUngraded(ctx, resultID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUngraded", reflect.TypeOf((*MockRepository)(nil).CountUngraded), ctx, resultID)
+}
+
+// ExportResults mocks base method.
+func (m *MockRepository) ExportResults(ctx context.Context, quizID int, fn func(models.ResultExport) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportResults", ctx, quizID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportResults indicates an expected call of ExportResults.
+func (mr *MockRepositoryMockRecorder) ExportResults(ctx, quizID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportResults", reflect.TypeOf((*MockRepository)(nil).ExportResults), ctx, quizID, fn)
+}
+
+// FinalizeResult mocks base method.
+func (m *MockRepository) FinalizeResult(ctx context.Context, resultID int, score, maxScore float64) (models.UsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinalizeResult", ctx, resultID, score, maxScore)
+	ret0, _ := ret[0].(models.UsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinalizeResult indicates an expected call of FinalizeResult.
+func (mr *MockRepositoryMockRecorder) FinalizeResult(ctx, resultID, score, maxScore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizeResult", reflect.TypeOf((*MockRepository)(nil).FinalizeResult), ctx, resultID, score, maxScore)
+}
+
+// GetAttemptsByQuizID mocks base method.
+func (m *MockRepository) GetAttemptsByQuizID(ctx context.Context, quizID int) ([]models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttemptsByQuizID", ctx, quizID)
+	ret0, _ := ret[0].([]models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttemptsByQuizID indicates an expected call of GetAttemptsByQuizID.
+func (mr *MockRepositoryMockRecorder) GetAttemptsByQuizID(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttemptsByQuizID", reflect.TypeOf((*MockRepository)(nil).GetAttemptsByQuizID), ctx, quizID)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id int) (models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByQuizID mocks base method.
+func (m *MockRepository) GetByQuizID(ctx context.Context, quizID int, scorePolicy string) ([]models.UsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByQuizID", ctx, quizID, scorePolicy)
+	ret0, _ := ret[0].([]models.UsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByQuizID indicates an expected call of GetByQuizID.
+func (mr *MockRepositoryMockRecorder) GetByQuizID(ctx, quizID, scorePolicy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByQuizID", reflect.TypeOf((*MockRepository)(nil).GetByQuizID), ctx, quizID, scorePolicy)
+}
+
+// GetByUserID mocks base method.
+func (m *MockRepository) GetByUserID(ctx context.Context, userID, quizID int) (models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID, quizID)
+	ret0, _ := ret[0].(models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockRepositoryMockRecorder) GetByUserID(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockRepository)(nil).GetByUserID), ctx, userID, quizID)
+}
+
+// GetExpired mocks base method.
+func (m *MockRepository) GetExpired(ctx context.Context) ([]models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpired", ctx)
+	ret0, _ := ret[0].([]models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpired indicates an expected call of GetExpired.
+func (mr *MockRepositoryMockRecorder) GetExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpired", reflect.TypeOf((*MockRepository)(nil).GetExpired), ctx)
+}
+
+// GetGradingQueue mocks base method.
+func (m *MockRepository) GetGradingQueue(ctx context.Context, quizID int) ([]models.GradingItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGradingQueue", ctx, quizID)
+	ret0, _ := ret[0].([]models.GradingItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGradingQueue indicates an expected call of GetGradingQueue.
+func (mr *MockRepositoryMockRecorder) GetGradingQueue(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGradingQueue", reflect.TypeOf((*MockRepository)(nil).GetGradingQueue), ctx, quizID)
+}
+
+// GetLastAttempt mocks base method.
+func (m *MockRepository) GetLastAttempt(ctx context.Context, userID, quizID int) (models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastAttempt", ctx, userID, quizID)
+	ret0, _ := ret[0].(models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastAttempt indicates an expected call of GetLastAttempt.
+func (mr *MockRepositoryMockRecorder) GetLastAttempt(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAttempt", reflect.TypeOf((*MockRepository)(nil).GetLastAttempt), ctx, userID, quizID)
+}
+
+// GetLeaderboard mocks base method.
+func (m *MockRepository) GetLeaderboard(ctx context.Context, quizID int, scorePolicy, period string, page, size int) (models.Leaderboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", ctx, quizID, scorePolicy, period, page, size)
+	ret0, _ := ret[0].(models.Leaderboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *MockRepositoryMockRecorder) GetLeaderboard(ctx, quizID, scorePolicy, period, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*MockRepository)(nil).GetLeaderboard), ctx, quizID, scorePolicy, period, page, size)
+}
+
+// GetLeaderboardRank mocks base method.
+func (m *MockRepository) GetLeaderboardRank(ctx context.Context, quizID, userID int, scorePolicy, period string) (models.UsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboardRank", ctx, quizID, userID, scorePolicy, period)
+	ret0, _ := ret[0].(models.UsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboardRank indicates an expected call of GetLeaderboardRank.
+func (mr *MockRepositoryMockRecorder) GetLeaderboardRank(ctx, quizID, userID, scorePolicy, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboardRank", reflect.TypeOf((*MockRepository)(nil).GetLeaderboardRank), ctx, quizID, userID, scorePolicy, period)
+}
+
+// GetResultQuestions mocks base method.
+func (m *MockRepository) GetResultQuestions(ctx context.Context, resultID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResultQuestions", ctx, resultID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResultQuestions indicates an expected call of GetResultQuestions.
+func (mr *MockRepositoryMockRecorder) GetResultQuestions(ctx, resultID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultQuestions", reflect.TypeOf((*MockRepository)(nil).GetResultQuestions), ctx, resultID)
+}
+
+// GetResultQuestionsByQuizID mocks base method.
+func (m *MockRepository) GetResultQuestionsByQuizID(ctx context.Context, quizID int) ([]models.ResultQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResultQuestionsByQuizID", ctx, quizID)
+	ret0, _ := ret[0].([]models.ResultQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResultQuestionsByQuizID indicates an expected call of GetResultQuestionsByQuizID.
+func (mr *MockRepositoryMockRecorder) GetResultQuestionsByQuizID(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultQuestionsByQuizID", reflect.TypeOf((*MockRepository)(nil).GetResultQuestionsByQuizID), ctx, quizID)
+}
+
+// GetResultUser mocks base method.
+func (m *MockRepository) GetResultUser(ctx context.Context, resultID int) (models.ShortUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResultUser", ctx, resultID)
+	ret0, _ := ret[0].(models.ShortUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResultUser indicates an expected call of GetResultUser.
+func (mr *MockRepositoryMockRecorder) GetResultUser(ctx, resultID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultUser", reflect.TypeOf((*MockRepository)(nil).GetResultUser), ctx, resultID)
+}
+
+// GetUserAnswerByID mocks base method.
+func (m *MockRepository) GetUserAnswerByID(ctx context.Context, id int) (models.UserAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAnswerByID", ctx, id)
+	ret0, _ := ret[0].(models.UserAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAnswerByID indicates an expected call of GetUserAnswerByID.
+func (mr *MockRepositoryMockRecorder) GetUserAnswerByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAnswerByID", reflect.TypeOf((*MockRepository)(nil).GetUserAnswerByID), ctx, id)
+}
+
+// GetUserAnswers mocks base method.
+func (m *MockRepository) GetUserAnswers(ctx context.Context, resultID int) ([]models.UserAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAnswers", ctx, resultID)
+	ret0, _ := ret[0].([]models.UserAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAnswers indicates an expected call of GetUserAnswers.
+func (mr *MockRepositoryMockRecorder) GetUserAnswers(ctx, resultID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAnswers", reflect.TypeOf((*MockRepository)(nil).GetUserAnswers), ctx, resultID)
+}
+
+// GetUserAnswersByQuizID mocks base method.
+func (m *MockRepository) GetUserAnswersByQuizID(ctx context.Context, quizID int) ([]models.UserAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAnswersByQuizID", ctx, quizID)
+	ret0, _ := ret[0].([]models.UserAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAnswersByQuizID indicates an expected call of GetUserAnswersByQuizID.
+func (mr *MockRepositoryMockRecorder) GetUserAnswersByQuizID(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAnswersByQuizID", reflect.TypeOf((*MockRepository)(nil).GetUserAnswersByQuizID), ctx, quizID)
+}
+
+// GradeAnswer mocks base method.
+func (m *MockRepository) GradeAnswer(ctx context.Context, id int, points float64, feedback string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GradeAnswer", ctx, id, points, feedback)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GradeAnswer indicates an expected call of GradeAnswer.
+func (mr *MockRepositoryMockRecorder) GradeAnswer(ctx, id, points, feedback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GradeAnswer", reflect.TypeOf((*MockRepository)(nil).GradeAnswer), ctx, id, points, feedback)
+}
+
+// LockExpired mocks base method.
+func (m *MockRepository) LockExpired(ctx context.Context, fn func() error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockExpired", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockExpired indicates an expected call of LockExpired.
+func (mr *MockRepositoryMockRecorder) LockExpired(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockExpired", reflect.TypeOf((*MockRepository)(nil).LockExpired), ctx, fn)
+}
+
+// NewResult mocks base method.
+func (m *MockRepository) NewResult(ctx context.Context, userID, quizID, versionID, timeLimit, maxAttempts int, questionIDs []int) (models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewResult", ctx, userID, quizID, versionID, timeLimit, maxAttempts, questionIDs)
+	ret0, _ := ret[0].(models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewResult indicates an expected call of NewResult.
+func (mr *MockRepositoryMockRecorder) NewResult(ctx, userID, quizID, versionID, timeLimit, maxAttempts, questionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewResult", reflect.TypeOf((*MockRepository)(nil).NewResult), ctx, userID, quizID, versionID, timeLimit, maxAttempts, questionIDs)
+}
+
+// SaveUserAnswers mocks base method.
+func (m *MockRepository) SaveUserAnswers(ctx context.Context, resultID, questionID int, answers []models.UserAnswer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserAnswers", ctx, resultID, questionID, answers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserAnswers indicates an expected call of SaveUserAnswers.
+func (mr *MockRepositoryMockRecorder) SaveUserAnswers(ctx, resultID, questionID, answers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserAnswers", reflect.TypeOf((*MockRepository)(nil).SaveUserAnswers), ctx, resultID, questionID, answers)
+}
+
+// SubmitResult mocks base method.
+func (m *MockRepository) SubmitResult(ctx context.Context, userID, resultID int, score, maxScore float64, pendingReview bool) (models.UsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitResult", ctx, userID, resultID, score, maxScore, pendingReview)
+	ret0, _ := ret[0].(models.UsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitResult indicates an expected call of SubmitResult.
+func (mr *MockRepositoryMockRecorder) SubmitResult(ctx, userID, resultID, score, maxScore, pendingReview any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitResult", reflect.TypeOf((*MockRepository)(nil).SubmitResult), ctx, userID, resultID, score, maxScore, pendingReview)
+}
@@ -0,0 +1,278 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/result/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/result/service.go -destination internal/result/mock/service_mock.go
+//
+// Package mock_result is a generated GoMock package.
+package mock_result
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/blazee5/quizmaster-backend/internal/domain"
+	models "github.com/blazee5/quizmaster-backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportResults mocks base method.
+func (m *MockService) ExportResults(ctx context.Context, userID, quizID int, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportResults", ctx, userID, quizID, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportResults indicates an expected call of ExportResults.
+func (mr *MockServiceMockRecorder) ExportResults(ctx, userID, quizID, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportResults", reflect.TypeOf((*MockService)(nil).ExportResults), ctx, userID, quizID, format, w)
+}
+
+// ExportResultsAdmin mocks base method.
+func (m *MockService) ExportResultsAdmin(ctx context.Context, quizID int, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportResultsAdmin", ctx, quizID, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportResultsAdmin indicates an expected call of ExportResultsAdmin.
+func (mr *MockServiceMockRecorder) ExportResultsAdmin(ctx, quizID, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportResultsAdmin", reflect.TypeOf((*MockService)(nil).ExportResultsAdmin), ctx, quizID, format, w)
+}
+
+// FinalizeResult mocks base method.
+func (m *MockService) FinalizeResult(ctx context.Context, userID, quizID, resultID int) (models.UsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinalizeResult", ctx, userID, quizID, resultID)
+	ret0, _ := ret[0].(models.UsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinalizeResult indicates an expected call of FinalizeResult.
+func (mr *MockServiceMockRecorder) FinalizeResult(ctx, userID, quizID, resultID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizeResult", reflect.TypeOf((*MockService)(nil).FinalizeResult), ctx, userID, quizID, resultID)
+}
+
+// GetAnalytics mocks base method.
+func (m *MockService) GetAnalytics(ctx context.Context, userID, quizID int) (models.QuizAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalytics", ctx, userID, quizID)
+	ret0, _ := ret[0].(models.QuizAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnalytics indicates an expected call of GetAnalytics.
+func (mr *MockServiceMockRecorder) GetAnalytics(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalytics", reflect.TypeOf((*MockService)(nil).GetAnalytics), ctx, userID, quizID)
+}
+
+// GetBroadcastResults mocks base method.
+func (m *MockService) GetBroadcastResults(ctx context.Context, quizID int) ([]models.UsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBroadcastResults", ctx, quizID)
+	ret0, _ := ret[0].([]models.UsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBroadcastResults indicates an expected call of GetBroadcastResults.
+func (mr *MockServiceMockRecorder) GetBroadcastResults(ctx, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastResults", reflect.TypeOf((*MockService)(nil).GetBroadcastResults), ctx, quizID)
+}
+
+// GetCurrentAttempt mocks base method.
+func (m *MockService) GetCurrentAttempt(ctx context.Context, userID, quizID int) (models.CurrentAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentAttempt", ctx, userID, quizID)
+	ret0, _ := ret[0].(models.CurrentAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentAttempt indicates an expected call of GetCurrentAttempt.
+func (mr *MockServiceMockRecorder) GetCurrentAttempt(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentAttempt", reflect.TypeOf((*MockService)(nil).GetCurrentAttempt), ctx, userID, quizID)
+}
+
+// GetGradingQueue mocks base method.
+func (m *MockService) GetGradingQueue(ctx context.Context, userID, quizID int) ([]models.GradingItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGradingQueue", ctx, userID, quizID)
+	ret0, _ := ret[0].([]models.GradingItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGradingQueue indicates an expected call of GetGradingQueue.
+func (mr *MockServiceMockRecorder) GetGradingQueue(ctx, userID, quizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGradingQueue", reflect.TypeOf((*MockService)(nil).GetGradingQueue), ctx, userID, quizID)
+}
+
+// GetLeaderboard mocks base method.
+func (m *MockService) GetLeaderboard(ctx context.Context, userID, quizID int, accessCode, period string, page, size int) (models.Leaderboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", ctx, userID, quizID, accessCode, period, page, size)
+	ret0, _ := ret[0].(models.Leaderboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *MockServiceMockRecorder) GetLeaderboard(ctx, userID, quizID, accessCode, period, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*MockService)(nil).GetLeaderboard), ctx, userID, quizID, accessCode, period, page, size)
+}
+
+// GetResult mocks base method.
+func (m *MockService) GetResult(ctx context.Context, userID, quizID, resultID int) (models.ResultReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResult", ctx, userID, quizID, resultID)
+	ret0, _ := ret[0].(models.ResultReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResult indicates an expected call of GetResult.
+func (mr *MockServiceMockRecorder) GetResult(ctx, userID, quizID, resultID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResult", reflect.TypeOf((*MockService)(nil).GetResult), ctx, userID, quizID, resultID)
+}
+
+// GetResultsByQuizID mocks base method.
+func (m *MockService) GetResultsByQuizID(ctx context.Context, userID, quizID int, accessCode string) ([]models.UsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResultsByQuizID", ctx, userID, quizID, accessCode)
+	ret0, _ := ret[0].([]models.UsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResultsByQuizID indicates an expected call of GetResultsByQuizID.
+func (mr *MockServiceMockRecorder) GetResultsByQuizID(ctx, userID, quizID, accessCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultsByQuizID", reflect.TypeOf((*MockService)(nil).GetResultsByQuizID), ctx, userID, quizID, accessCode)
+}
+
+// GradeAnswer mocks base method.
+func (m *MockService) GradeAnswer(ctx context.Context, userID, quizID, answerID int, input domain.GradeAnswer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GradeAnswer", ctx, userID, quizID, answerID, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GradeAnswer indicates an expected call of GradeAnswer.
+func (mr *MockServiceMockRecorder) GradeAnswer(ctx, userID, quizID, answerID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GradeAnswer", reflect.TypeOf((*MockService)(nil).GradeAnswer), ctx, userID, quizID, answerID, input)
+}
+
+// GradeQuestion mocks base method.
+func (m *MockService) GradeQuestion(question models.QuestionWithAnswers, answers []models.UserAnswer) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GradeQuestion", question, answers)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GradeQuestion indicates an expected call of GradeQuestion.
+func (mr *MockServiceMockRecorder) GradeQuestion(question, answers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GradeQuestion", reflect.TypeOf((*MockService)(nil).GradeQuestion), question, answers)
+}
+
+// NewResult mocks base method.
+func (m *MockService) NewResult(ctx context.Context, userID, quizID int, accessCode string) (models.Attempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewResult", ctx, userID, quizID, accessCode)
+	ret0, _ := ret[0].(models.Attempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewResult indicates an expected call of NewResult.
+func (mr *MockServiceMockRecorder) NewResult(ctx, userID, quizID, accessCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewResult", reflect.TypeOf((*MockService)(nil).NewResult), ctx, userID, quizID, accessCode)
+}
+
+// SaveUserAnswer mocks base method.
+func (m *MockService) SaveUserAnswer(ctx context.Context, userID, quizID int, input domain.UserAnswer) (models.Attempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserAnswer", ctx, userID, quizID, input)
+	ret0, _ := ret[0].(models.Attempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveUserAnswer indicates an expected call of SaveUserAnswer.
+func (mr *MockServiceMockRecorder) SaveUserAnswer(ctx, userID, quizID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserAnswer", reflect.TypeOf((*MockService)(nil).SaveUserAnswer), ctx, userID, quizID, input)
+}
+
+// SubmitExpiredResults mocks base method.
+func (m *MockService) SubmitExpiredResults(ctx context.Context) ([]models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitExpiredResults", ctx)
+	ret0, _ := ret[0].([]models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitExpiredResults indicates an expected call of SubmitExpiredResults.
+func (mr *MockServiceMockRecorder) SubmitExpiredResults(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitExpiredResults", reflect.TypeOf((*MockService)(nil).SubmitExpiredResults), ctx)
+}
+
+// SubmitResult mocks base method.
+func (m *MockService) SubmitResult(ctx context.Context, userID, quizID int, input domain.SubmitResult) (models.UsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitResult", ctx, userID, quizID, input)
+	ret0, _ := ret[0].(models.UsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitResult indicates an expected call of SubmitResult.
+func (mr *MockServiceMockRecorder) SubmitResult(ctx, userID, quizID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitResult", reflect.TypeOf((*MockService)(nil).SubmitResult), ctx, userID, quizID, input)
+}
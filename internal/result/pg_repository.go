@@ -7,11 +7,27 @@ import (
 
 type Repository interface {
 	GetByID(ctx context.Context, id int) (models.Result, error)
-	GetByQuizID(ctx context.Context, quizID int) ([]models.UsersResult, error)
-	GetByUserID(ctx context.Context, id int) (models.Result, error)
-	GetUserAnswerByID(ctx context.Context, answerID, resultID int) (bool, error)
-	NewResult(ctx context.Context, userID, quizID int) (int, error)
-	UpdateResult(ctx context.Context, id, userID, score int) error
-	SaveUserAnswer(ctx context.Context, userID, questionID, answerID, resultID int, answerText string) error
-	SubmitResult(ctx context.Context, userID, resultID int) (models.UsersResult, error)
+	GetByQuizID(ctx context.Context, quizID int, scorePolicy string) ([]models.UsersResult, error)
+	GetLeaderboard(ctx context.Context, quizID int, scorePolicy, period string, page, size int) (models.Leaderboard, error)
+	GetLeaderboardRank(ctx context.Context, quizID, userID int, scorePolicy, period string) (models.UsersResult, error)
+	GetByUserID(ctx context.Context, userID, quizID int) (models.Result, error)
+	GetResultQuestions(ctx context.Context, resultID int) ([]int, error)
+	GetAttemptsByQuizID(ctx context.Context, quizID int) ([]models.Result, error)
+	GetUserAnswersByQuizID(ctx context.Context, quizID int) ([]models.UserAnswer, error)
+	GetResultQuestionsByQuizID(ctx context.Context, quizID int) ([]models.ResultQuestion, error)
+	ExportResults(ctx context.Context, quizID int, fn func(result models.ResultExport) error) error
+	GetUserAnswers(ctx context.Context, resultID int) ([]models.UserAnswer, error)
+	CountAttempts(ctx context.Context, userID, quizID int) (int, error)
+	GetLastAttempt(ctx context.Context, userID, quizID int) (models.Result, error)
+	GetExpired(ctx context.Context) ([]models.Result, error)
+	LockExpired(ctx context.Context, fn func() error) error
+	NewResult(ctx context.Context, userID, quizID, versionID, timeLimit, maxAttempts int, questionIDs []int) (models.Result, error)
+	SaveUserAnswers(ctx context.Context, resultID, questionID int, answers []models.UserAnswer) error
+	SubmitResult(ctx context.Context, userID, resultID int, score, maxScore float64, pendingReview bool) (models.UsersResult, error)
+	GetGradingQueue(ctx context.Context, quizID int) ([]models.GradingItem, error)
+	GetUserAnswerByID(ctx context.Context, id int) (models.UserAnswer, error)
+	GradeAnswer(ctx context.Context, id int, points float64, feedback string) error
+	CountUngraded(ctx context.Context, resultID int) (int, error)
+	GetResultUser(ctx context.Context, resultID int) (models.ShortUser, error)
+	FinalizeResult(ctx context.Context, resultID int, score, maxScore float64) (models.UsersResult, error)
 }
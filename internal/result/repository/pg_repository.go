@@ -2,12 +2,19 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
 	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"math"
 )
 
+// expiredLockKey is the advisory lock taken while submitting expired results.
+const expiredLockKey = 7310001
+
 type Repository struct {
 	db     *sqlx.DB
 	tracer trace.Tracer
@@ -17,23 +24,65 @@ func NewRepository(db *sqlx.DB, tracer trace.Tracer) *Repository {
 	return &Repository{db: db, tracer: tracer}
 }
 
-func (repo *Repository) NewResult(ctx context.Context, userID, quizID int) (int, error) {
+// NewResult starts an attempt. With maxAttempts above zero it returns
+// sql.ErrNoRows when the user already used them all, the count is taken under a
+// per user and quiz lock so concurrent starts can't exceed it.
+func (repo *Repository) NewResult(ctx context.Context, userID, quizID, versionID, timeLimit, maxAttempts int, questionIDs []int) (models.Result, error) {
 	ctx, span := repo.tracer.Start(ctx, "resultRepo.NewResult")
 	defer span.End()
 
-	var id int
+	var result models.Result
 
-	err := repo.db.QueryRowxContext(ctx, "INSERT INTO results (user_id, quiz_id, score) VALUES ($1, $2, $3) RETURNING id",
-		userID, quizID, 0).Scan(&id)
+	tx, err := repo.db.BeginTxx(ctx, nil)
 
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return 0, err
+		return models.Result{}, err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1, $2)", userID, quizID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Result{}, err
 	}
 
-	return id, nil
+	err = tx.QueryRowxContext(ctx, `INSERT INTO results (user_id, quiz_id, version_id, score, expires_at)
+		SELECT $1, $2, $3, $4, LEAST(NOW() + NULLIF($5, 0) * INTERVAL '1 second', (SELECT closes_at FROM quizzes WHERE id = $2))
+		WHERE $6 = 0 OR (SELECT COUNT(id) FROM results WHERE user_id = $1 AND quiz_id = $2) < $6
+		RETURNING *`,
+		userID, quizID, versionID, 0, timeLimit, maxAttempts).StructScan(&result)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Result{}, err
+	}
+
+	for _, questionID := range questionIDs {
+		_, err := tx.ExecContext(ctx, "INSERT INTO result_questions (result_id, question_id) VALUES ($1, $2)", result.ID, questionID)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return models.Result{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Result{}, err
+	}
+
+	return result, nil
 }
 
 func (repo *Repository) GetByID(ctx context.Context, id int) (models.Result, error) {
@@ -42,7 +91,7 @@ func (repo *Repository) GetByID(ctx context.Context, id int) (models.Result, err
 
 	var result models.Result
 
-	err := repo.db.QueryRowxContext(ctx, "SELECT * FROM results WHERE id = $1", id).StructScan(&result)
+	err := repo.db.QueryRowxContext(ctx, "SELECT *, COALESCE(score * 100 / NULLIF(max_score, 0), 0) AS percentage FROM results WHERE id = $1", id).StructScan(&result)
 
 	if err != nil {
 		span.RecordError(err)
@@ -54,42 +103,200 @@ func (repo *Repository) GetByID(ctx context.Context, id int) (models.Result, err
 	return result, nil
 }
 
-func (repo *Repository) GetByQuizID(ctx context.Context, quizID int) ([]models.UsersResult, error) {
+func (repo *Repository) GetByQuizID(ctx context.Context, quizID int, scorePolicy string) ([]models.UsersResult, error) {
 	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetByQuizID")
 	defer span.End()
 
 	results := make([]models.UsersResult, 0)
 
-	err := repo.db.SelectContext(ctx, &results, `
+	err := repo.db.SelectContext(ctx, &results, usersResultsQuery(scorePolicy, ""), quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (repo *Repository) GetLeaderboard(ctx context.Context, quizID int, scorePolicy, period string, page, size int) (models.Leaderboard, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetLeaderboard")
+	defer span.End()
+
+	var total int
+
+	query := usersResultsQuery(scorePolicy, periodFilter(period))
+
+	err := repo.db.QueryRowxContext(ctx, "SELECT COUNT(*) FROM ("+query+") AS leaderboard", quizID).Scan(&total)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Leaderboard{}, err
+	}
+
+	results := make([]models.UsersResult, 0)
+
+	err = repo.db.SelectContext(ctx, &results, `SELECT *, DENSE_RANK() OVER (ORDER BY score DESC) AS rank
+		FROM (`+query+`) AS leaderboard
+		ORDER BY rank, duration NULLS LAST, id
+		LIMIT $2 OFFSET $3`, quizID, size, (page-1)*size)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Leaderboard{}, err
+	}
+
+	return models.Leaderboard{
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(size))),
+		Page:       page,
+		Size:       size,
+		Results:    results,
+	}, nil
+}
+
+func (repo *Repository) GetLeaderboardRank(ctx context.Context, quizID, userID int, scorePolicy, period string) (models.UsersResult, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetLeaderboardRank")
+	defer span.End()
+
+	var result models.UsersResult
+
+	err := repo.db.QueryRowxContext(ctx, `SELECT * FROM (
+			SELECT *, DENSE_RANK() OVER (ORDER BY score DESC) AS rank
+			FROM (`+usersResultsQuery(scorePolicy, periodFilter(period))+`) AS leaderboard
+		) AS ranked WHERE user_id = $2`, quizID, userID).StructScan(&result)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.UsersResult{}, err
+	}
+
+	return result, nil
+}
+
+// usersResultsQuery selects one row per user for the quiz in $1 according to the score policy.
+func usersResultsQuery(scorePolicy, filter string) string {
+	switch scorePolicy {
+	case "average":
+		return `
+        SELECT
+            u.id AS user_id,
+            u.avatar,
+            MAX(r.id) AS id,
+            AVG(r.score) AS score,
+            AVG(r.max_score) AS max_score,
+            COALESCE(AVG(r.score * 100 / NULLIF(r.max_score, 0)), 0) AS percentage,
+            MAX(r.created_at) AS created_at,
+            MAX(r.submitted_at) AS submitted_at,
+            AVG(EXTRACT(EPOCH FROM r.submitted_at - r.created_at))::INT AS duration,
+            u.username
+        FROM results r
+        JOIN users u ON u.id = r.user_id
+        WHERE r.quiz_id = $1 AND r.is_completed = true AND r.pending_review = false` + filter + `
+        GROUP BY u.id
+    `
+	case "latest":
+		return `
+        SELECT DISTINCT ON (r.user_id)
+            u.id AS user_id,
+            u.avatar,
+            r.id,
+            r.score,
+            r.max_score,
+            COALESCE(r.score * 100 / NULLIF(r.max_score, 0), 0) AS percentage,
+            r.created_at,
+            r.submitted_at,
+            EXTRACT(EPOCH FROM r.submitted_at - r.created_at)::INT AS duration,
+            u.username
+        FROM results r
+        JOIN users u ON u.id = r.user_id
+        WHERE r.quiz_id = $1 AND r.is_completed = true AND r.pending_review = false` + filter + `
+        ORDER BY r.user_id, r.created_at DESC, r.id DESC
+    `
+	default:
+		return `
         SELECT DISTINCT ON (r.user_id)
             u.id AS user_id,
             u.avatar,
             r.id,
             r.score,
+            r.max_score,
+            COALESCE(r.score * 100 / NULLIF(r.max_score, 0), 0) AS percentage,
             r.created_at,
+            r.submitted_at,
+            EXTRACT(EPOCH FROM r.submitted_at - r.created_at)::INT AS duration,
             u.username
         FROM results r
         JOIN users u ON u.id = r.user_id
-        WHERE r.quiz_id = $1 AND r.is_completed = true
-        ORDER BY r.user_id, r.score DESC, r.created_at DESC, r.id DESC
-    `, quizID)
+        WHERE r.quiz_id = $1 AND r.is_completed = true AND r.pending_review = false` + filter + `
+        ORDER BY r.user_id, r.score DESC, r.submitted_at - r.created_at, r.id DESC
+    `
+	}
+}
+
+func periodFilter(period string) string {
+	switch period {
+	case "today":
+		return " AND COALESCE(r.submitted_at, r.created_at) >= date_trunc('day', NOW())"
+	case "week":
+		return " AND COALESCE(r.submitted_at, r.created_at) >= NOW() - INTERVAL '7 days'"
+	default:
+		return ""
+	}
+}
+
+func (repo *Repository) GetByUserID(ctx context.Context, userID, quizID int) (models.Result, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetByUserID")
+	defer span.End()
+
+	var result models.Result
+
+	err := repo.db.QueryRowxContext(ctx, `SELECT *, COALESCE(score * 100 / NULLIF(max_score, 0), 0) AS percentage FROM results
+		WHERE user_id = $1 AND quiz_id = $2 AND is_completed = false ORDER BY created_at DESC, id DESC LIMIT 1`, userID, quizID).StructScan(&result)
 
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, err
+
+		return models.Result{}, err
 	}
 
-	return results, nil
+	return result, nil
 }
 
-func (repo *Repository) GetByUserID(ctx context.Context, id int) (models.Result, error) {
-	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetByUserID")
+func (repo *Repository) CountAttempts(ctx context.Context, userID, quizID int) (int, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.CountAttempts")
+	defer span.End()
+
+	var count int
+
+	err := repo.db.QueryRowxContext(ctx, "SELECT COUNT(id) FROM results WHERE user_id = $1 AND quiz_id = $2", userID, quizID).Scan(&count)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repo *Repository) GetLastAttempt(ctx context.Context, userID, quizID int) (models.Result, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetLastAttempt")
 	defer span.End()
 
 	var result models.Result
 
-	err := repo.db.QueryRowxContext(ctx, "SELECT * FROM results WHERE user_id = $1 AND is_completed = false", id).StructScan(&result)
+	err := repo.db.QueryRowxContext(ctx, `SELECT *, COALESCE(score * 100 / NULLIF(max_score, 0), 0) AS percentage FROM results
+		WHERE user_id = $1 AND quiz_id = $2 ORDER BY created_at DESC, id DESC LIMIT 1`, userID, quizID).StructScan(&result)
 
 	if err != nil {
 		span.RecordError(err)
@@ -101,30 +308,34 @@ func (repo *Repository) GetByUserID(ctx context.Context, id int) (models.Result,
 	return result, nil
 }
 
-func (repo *Repository) GetUserAnswerByID(ctx context.Context, answerID, resultID int) (bool, error) {
-	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetUserAnswerByID")
+func (repo *Repository) GetExpired(ctx context.Context) ([]models.Result, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetExpired")
 	defer span.End()
 
-	var result int
+	results := make([]models.Result, 0)
 
-	err := repo.db.QueryRowxContext(ctx, "SELECT COUNT(id) FROM user_answers WHERE answer_id = $1 AND result_id = $2", answerID, resultID).Scan(&result)
+	err := repo.db.SelectContext(ctx, &results, `SELECT r.* FROM results r
+		INNER JOIN quizzes q ON q.id = r.quiz_id
+		WHERE r.is_completed = false AND (r.expires_at < NOW() OR q.closes_at < NOW())`)
 
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return false, err
+		return nil, err
 	}
 
-	return result > 0, nil
+	return results, nil
 }
 
-func (repo *Repository) SaveUserAnswer(ctx context.Context, userID, questionID, answerID, resultID int, answerText string) error {
-	ctx, span := repo.tracer.Start(ctx, "resultRepo.SaveUserAnswer")
+// LockExpired runs fn under a transaction-level advisory lock, so only one
+// replica submits expired results at a time. fn is skipped while another
+// replica holds the lock.
+func (repo *Repository) LockExpired(ctx context.Context, fn func() error) error {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.LockExpired")
 	defer span.End()
 
-	_, err := repo.db.ExecContext(ctx, "INSERT INTO user_answers (user_id, question_id, answer_id, result_id, text) VALUES ($1, $2, $3, $4, $5)",
-		userID, questionID, answerID, resultID, answerText)
+	tx, err := repo.db.BeginTxx(ctx, nil)
 
 	if err != nil {
 		span.RecordError(err)
@@ -133,33 +344,256 @@ func (repo *Repository) SaveUserAnswer(ctx context.Context, userID, questionID,
 		return err
 	}
 
-	return nil
+	defer tx.Rollback()
+
+	var locked bool
+
+	err = tx.QueryRowxContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", expiredLockKey).Scan(&locked)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if !locked {
+		return nil
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *Repository) GetResultQuestions(ctx context.Context, resultID int) ([]int, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetResultQuestions")
+	defer span.End()
+
+	questionIDs := make([]int, 0)
+
+	err := repo.db.SelectContext(ctx, &questionIDs, "SELECT question_id FROM result_questions WHERE result_id = $1", resultID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return questionIDs, nil
 }
 
-func (repo *Repository) UpdateResult(ctx context.Context, id, userID, score int) error {
-	ctx, span := repo.tracer.Start(ctx, "resultRepo.UpdateResult")
+func (repo *Repository) GetAttemptsByQuizID(ctx context.Context, quizID int) ([]models.Result, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetAttemptsByQuizID")
 	defer span.End()
 
-	_, err := repo.db.ExecContext(ctx, "UPDATE results SET score = score + $1 WHERE id = $2 AND user_id = $3",
-		score, id, userID)
+	results := make([]models.Result, 0)
+
+	err := repo.db.SelectContext(ctx, &results, `SELECT *, COALESCE(score * 100 / NULLIF(max_score, 0), 0) AS percentage
+		FROM results WHERE quiz_id = $1`, quizID)
 
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (repo *Repository) GetUserAnswersByQuizID(ctx context.Context, quizID int) ([]models.UserAnswer, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetUserAnswersByQuizID")
+	defer span.End()
+
+	answers := make([]models.UserAnswer, 0)
+
+	err := repo.db.SelectContext(ctx, &answers, `SELECT ua.id, ua.user_id, ua.question_id, ua.answer_id, ua.result_id, ua.text
+		FROM user_answers ua
+		JOIN results r ON r.id = ua.result_id
+		WHERE r.quiz_id = $1 AND r.is_completed = true
+		ORDER BY ua.id`, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+func (repo *Repository) GetResultQuestionsByQuizID(ctx context.Context, quizID int) ([]models.ResultQuestion, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetResultQuestionsByQuizID")
+	defer span.End()
+
+	questions := make([]models.ResultQuestion, 0)
+
+	err := repo.db.SelectContext(ctx, &questions, `SELECT rq.result_id, rq.question_id
+		FROM result_questions rq
+		JOIN results r ON r.id = rq.result_id
+		WHERE r.quiz_id = $1 AND r.is_completed = true`, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return questions, nil
+}
+
+func (repo *Repository) ExportResults(ctx context.Context, quizID int, fn func(result models.ResultExport) error) error {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.ExportResults")
+	defer span.End()
+
+	rows, err := repo.db.QueryxContext(ctx, `SELECT r.id AS result_id, u.username, u.email, r.score, r.max_score,
+		COALESCE(r.score * 100 / NULLIF(r.max_score, 0), 0) AS percentage, r.created_at, r.submitted_at,
+		CASE WHEN NOT r.is_completed THEN 'in_progress' WHEN r.pending_review THEN 'pending_review' ELSE 'completed' END AS status,
+		r.version_id, ua.question_id, COALESCE(ua.answer_id, 0) AS answer_id, COALESCE(ua.text, '') AS text
+		FROM results r
+		JOIN users u ON u.id = r.user_id
+		LEFT JOIN user_answers ua ON ua.result_id = r.id
+		WHERE r.quiz_id = $1
+		ORDER BY r.id, ua.id`, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	defer rows.Close()
+
+	var current *models.ResultExport
+
+	for rows.Next() {
+		var row models.ResultExport
+
+		if err := rows.StructScan(&row); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return err
+		}
+
+		if current == nil || current.ResultID != row.ResultID {
+			if current != nil {
+				if err := fn(*current); err != nil {
+					return err
+				}
+			}
+
+			row.Answers = make(map[int][]models.UserAnswer)
+			current = &row
+		}
+
+		if row.QuestionID != nil {
+			current.Answers[*row.QuestionID] = append(current.Answers[*row.QuestionID], models.UserAnswer{
+				QuestionID: *row.QuestionID,
+				AnswerID:   row.AnswerID,
+				Text:       row.Text,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
 		return err
 	}
 
+	if current != nil {
+		return fn(*current)
+	}
+
 	return nil
 }
 
-func (repo *Repository) SubmitResult(ctx context.Context, userID, resultID int) (models.UsersResult, error) {
+func (repo *Repository) GetUserAnswers(ctx context.Context, resultID int) ([]models.UserAnswer, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetUserAnswers")
+	defer span.End()
+
+	answers := make([]models.UserAnswer, 0)
+
+	err := repo.db.SelectContext(ctx, &answers, `SELECT ua.id, ua.user_id, ua.question_id, ua.answer_id, ua.result_id, ua.text,
+		COALESCE(a.text, '') AS answer_text, ua.needs_review, ua.points, ua.feedback
+		FROM user_answers ua
+		LEFT JOIN answers a ON a.id = ua.answer_id
+		WHERE ua.result_id = $1
+		ORDER BY ua.id`, resultID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+func (repo *Repository) SaveUserAnswers(ctx context.Context, resultID, questionID int, answers []models.UserAnswer) error {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.SaveUserAnswers")
+	defer span.End()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM user_answers WHERE result_id = $1 AND question_id = $2", resultID, questionID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	for _, answer := range answers {
+		_, err := tx.ExecContext(ctx, "INSERT INTO user_answers (user_id, question_id, answer_id, result_id, text, needs_review) VALUES ($1, $2, $3, $4, $5, $6)",
+			answer.UserID, questionID, answer.AnswerID, resultID, answer.Text, answer.NeedsReview)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *Repository) SubmitResult(ctx context.Context, userID, resultID int, score, maxScore float64, pendingReview bool) (models.UsersResult, error) {
 	ctx, span := repo.tracer.Start(ctx, "resultRepo.SubmitResult")
 	defer span.End()
 
 	var result models.UsersResult
 
-	err := repo.db.QueryRowxContext(ctx, "UPDATE results SET is_completed = true WHERE id = $1 AND user_id = $2", resultID, userID).Err()
+	err := repo.db.QueryRowxContext(ctx, `UPDATE results SET is_completed = true, score = $1, max_score = $2, pending_review = $3, submitted_at = NOW()
+		WHERE id = $4 AND user_id = $5 AND submitted_at IS NULL
+		RETURNING id, score, max_score, COALESCE(score * 100 / NULLIF(max_score, 0), 0) AS percentage, created_at, submitted_at,
+		EXTRACT(EPOCH FROM submitted_at - created_at)::INT AS duration, pending_review`,
+		score, maxScore, pendingReview, resultID, userID).StructScan(&result)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.UsersResult{}, http_errors.ErrAlreadySubmitted
+	}
 
 	if err != nil {
 		span.RecordError(err)
@@ -168,7 +602,117 @@ func (repo *Repository) SubmitResult(ctx context.Context, userID, resultID int)
 		return models.UsersResult{}, err
 	}
 
-	err = repo.db.QueryRowxContext(ctx, "SELECT id, score, created_at FROM results WHERE id = $1", resultID).StructScan(&result)
+	return result, nil
+}
+
+func (repo *Repository) GetGradingQueue(ctx context.Context, quizID int) ([]models.GradingItem, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetGradingQueue")
+	defer span.End()
+
+	items := make([]models.GradingItem, 0)
+
+	err := repo.db.SelectContext(ctx, &items, `SELECT ua.id, ua.result_id, ua.user_id, u.username, ua.question_id, ua.text, r.submitted_at, r.version_id
+		FROM user_answers ua
+		JOIN results r ON r.id = ua.result_id
+		JOIN users u ON u.id = ua.user_id
+		WHERE r.quiz_id = $1 AND r.pending_review = true AND ua.needs_review = true AND ua.points IS NULL
+		ORDER BY r.submitted_at, ua.id`, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (repo *Repository) GetUserAnswerByID(ctx context.Context, id int) (models.UserAnswer, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetUserAnswerByID")
+	defer span.End()
+
+	var answer models.UserAnswer
+
+	err := repo.db.QueryRowxContext(ctx, `SELECT id, user_id, question_id, answer_id, result_id, text, needs_review, points, feedback
+		FROM user_answers WHERE id = $1`, id).StructScan(&answer)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.UserAnswer{}, err
+	}
+
+	return answer, nil
+}
+
+func (repo *Repository) GradeAnswer(ctx context.Context, id int, points float64, feedback string) error {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GradeAnswer")
+	defer span.End()
+
+	_, err := repo.db.ExecContext(ctx, "UPDATE user_answers SET points = $1, feedback = $2 WHERE id = $3", points, feedback, id)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+func (repo *Repository) CountUngraded(ctx context.Context, resultID int) (int, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.CountUngraded")
+	defer span.End()
+
+	var count int
+
+	err := repo.db.QueryRowxContext(ctx, "SELECT COUNT(id) FROM user_answers WHERE result_id = $1 AND needs_review = true AND points IS NULL",
+		resultID).Scan(&count)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repo *Repository) GetResultUser(ctx context.Context, resultID int) (models.ShortUser, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.GetResultUser")
+	defer span.End()
+
+	var user models.ShortUser
+
+	err := repo.db.QueryRowxContext(ctx, `SELECT u.id, u.username, u.email, u.avatar
+		FROM results r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.id = $1`, resultID).StructScan(&user)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.ShortUser{}, err
+	}
+
+	return user, nil
+}
+
+func (repo *Repository) FinalizeResult(ctx context.Context, resultID int, score, maxScore float64) (models.UsersResult, error) {
+	ctx, span := repo.tracer.Start(ctx, "resultRepo.FinalizeResult")
+	defer span.End()
+
+	var result models.UsersResult
+
+	err := repo.db.QueryRowxContext(ctx, `UPDATE results SET score = $1, max_score = $2, pending_review = false WHERE id = $3
+		RETURNING id, user_id, score, max_score, COALESCE(score * 100 / NULLIF(max_score, 0), 0) AS percentage, created_at, submitted_at,
+		EXTRACT(EPOCH FROM submitted_at - created_at)::INT AS duration, pending_review`,
+		score, maxScore, resultID).StructScan(&result)
 
 	if err != nil {
 		span.RecordError(err)
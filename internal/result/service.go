@@ -4,11 +4,24 @@ import (
 	"context"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	"github.com/blazee5/quizmaster-backend/internal/models"
+	"io"
 )
 
 type Service interface {
-	NewResult(ctx context.Context, userID int, quizID int) (int, error)
-	SaveUserAnswer(ctx context.Context, userID, quizID int, input domain.UserAnswer) error
-	GetResultsByQuizID(ctx context.Context, quizID int) ([]models.UsersResult, error)
+	NewResult(ctx context.Context, userID int, quizID int, accessCode string) (models.Attempt, error)
+	GetCurrentAttempt(ctx context.Context, userID, quizID int) (models.CurrentAttempt, error)
+	SaveUserAnswer(ctx context.Context, userID, quizID int, input domain.UserAnswer) (models.Attempt, error)
+	GetResultsByQuizID(ctx context.Context, userID, quizID int, accessCode string) ([]models.UsersResult, error)
+	GetBroadcastResults(ctx context.Context, quizID int) ([]models.UsersResult, error)
+	GetLeaderboard(ctx context.Context, userID, quizID int, accessCode, period string, page, size int) (models.Leaderboard, error)
+	GetAnalytics(ctx context.Context, userID, quizID int) (models.QuizAnalytics, error)
+	ExportResults(ctx context.Context, userID, quizID int, format string, w io.Writer) error
+	ExportResultsAdmin(ctx context.Context, quizID int, format string, w io.Writer) error
+	GetResult(ctx context.Context, userID, quizID, resultID int) (models.ResultReview, error)
 	SubmitResult(ctx context.Context, userID, quizID int, input domain.SubmitResult) (models.UsersResult, error)
+	SubmitExpiredResults(ctx context.Context) ([]models.Result, error)
+	GetGradingQueue(ctx context.Context, userID, quizID int) ([]models.GradingItem, error)
+	GradeAnswer(ctx context.Context, userID, quizID, answerID int, input domain.GradeAnswer) error
+	FinalizeResult(ctx context.Context, userID, quizID, resultID int) (models.UsersResult, error)
+	GradeQuestion(question models.QuestionWithAnswers, answers []models.UserAnswer) float64
 }
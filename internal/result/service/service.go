@@ -2,214 +2,1760 @@ package service
 
 import (
 	"context"
-	"github.com/blazee5/quizmaster-backend/internal/answer"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/blazee5/quizmaster-backend/internal/domain"
 	"github.com/blazee5/quizmaster-backend/internal/models"
 	"github.com/blazee5/quizmaster-backend/internal/question"
+	"github.com/blazee5/quizmaster-backend/internal/question/content"
+	"github.com/blazee5/quizmaster-backend/internal/question/match"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
 	"github.com/blazee5/quizmaster-backend/internal/quiz"
+	"github.com/blazee5/quizmaster-backend/internal/quiz/visibility"
+	"github.com/blazee5/quizmaster-backend/internal/rabbitmq"
 	"github.com/blazee5/quizmaster-backend/internal/result"
+	"github.com/blazee5/quizmaster-backend/lib/export"
 	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/blazee5/quizmaster-backend/lib/mail"
+	"github.com/blazee5/quizmaster-backend/lib/markdown"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// maxEssayLength limits essay answers, in characters.
+const maxEssayLength = 10000
+
 type Service struct {
 	log          *zap.SugaredLogger
 	repo         result.Repository
 	quizRepo     quiz.Repository
 	questionRepo question.Repository
-	answerRepo   answer.Repository
+	producer     rabbitmq.QueueProducer
 	tracer       trace.Tracer
 }
 
-func NewService(log *zap.SugaredLogger, repo result.Repository, quizRepo quiz.Repository, questionRepo question.Repository, answerRepo answer.Repository, tracer trace.Tracer) *Service {
-	return &Service{log: log, repo: repo, quizRepo: quizRepo, questionRepo: questionRepo, answerRepo: answerRepo, tracer: tracer}
+func NewService(log *zap.SugaredLogger, repo result.Repository, quizRepo quiz.Repository, questionRepo question.Repository, producer rabbitmq.QueueProducer, tracer trace.Tracer) *Service {
+	return &Service{log: log, repo: repo, quizRepo: quizRepo, questionRepo: questionRepo, producer: producer, tracer: tracer}
 }
 
-func (s *Service) NewResult(ctx context.Context, userID int, quizID int) (int, error) {
+func (s *Service) NewResult(ctx context.Context, userID int, quizID int, accessCode string) (models.Attempt, error) {
 	ctx, span := s.tracer.Start(ctx, "resultService.NewResult")
 	defer span.End()
 
-	_, err := s.quizRepo.GetByID(ctx, quizID)
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		return models.Attempt{}, err
+	}
+
+	current, err := s.repo.GetByUserID(ctx, userID, quizID)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Attempt{}, err
+	}
+
+	if err == nil {
+		if current.ExpiresAt == nil || current.ExpiresAt.After(time.Now()) {
+			return newAttempt(current), nil
+		}
+
+		if _, err := s.submitResult(ctx, current); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return models.Attempt{}, err
+		}
+	}
+
+	if quiz.Status != "published" || quiz.VersionID == nil {
+		return models.Attempt{}, http_errors.ErrQuizNotPublished
+	}
+
+	if err := visibility.Check(ctx, s.quizRepo, quiz, userID, accessCode); err != nil {
+		return models.Attempt{}, err
+	}
+
+	now := time.Now()
+
+	if quiz.OpensAt != nil && now.Before(*quiz.OpensAt) {
+		return models.Attempt{}, http_errors.ErrQuizNotOpen
+	}
+
+	if quiz.ClosesAt != nil && !now.Before(*quiz.ClosesAt) {
+		return models.Attempt{}, http_errors.ErrQuizClosed
+	}
+
+	if err := s.checkAttemptPolicy(ctx, userID, quiz); err != nil {
+		return models.Attempt{}, err
+	}
+
+	var questionIDs []int
+
+	if quiz.PoolSize > 0 {
+		questions, err := s.getVersionQuestions(ctx, quiz.ID, quiz.VersionID)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return models.Attempt{}, err
+		}
+
+		questionIDs = drawQuestions(questions, quiz.PoolSize, quiz.PoolByTag)
+	}
+
+	result, err := s.repo.NewResult(ctx, userID, quizID, *quiz.VersionID, quiz.TimeLimit, quiz.MaxAttempts, questionIDs)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Attempt{}, http_errors.ErrAttemptsExceeded
+	}
 
 	if err != nil {
-		return 0, err
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Attempt{}, err
 	}
 
-	return s.repo.NewResult(ctx, userID, quizID)
+	return newAttempt(result), nil
 }
 
-func (s *Service) SaveUserAnswer(ctx context.Context, userID, quizID int, input domain.UserAnswer) error {
-	ctx, span := s.tracer.Start(ctx, "resultService.SaveUserAnswer")
+func (s *Service) GetCurrentAttempt(ctx context.Context, userID, quizID int) (models.CurrentAttempt, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.GetCurrentAttempt")
 	defer span.End()
 
-	if err := s.CheckPermissions(ctx, userID, quizID, input); err != nil {
-		return err
+	if _, err := s.quizRepo.GetByID(ctx, quizID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.CurrentAttempt{}, err
 	}
 
-	question, err := s.questionRepo.GetQuestionByID(ctx, input.QuestionID)
+	attempt, err := s.repo.GetByUserID(ctx, userID, quizID)
 
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return err
+		return models.CurrentAttempt{}, err
 	}
 
-	if question.QuizID != quizID {
-		return http_errors.ErrWrongArgument
+	if attempt.ExpiresAt != nil && attempt.ExpiresAt.Before(time.Now()) {
+		return models.CurrentAttempt{}, http_errors.ErrAttemptExpired
 	}
 
-	answerExists, err := s.repo.GetUserAnswerByID(ctx, input.AnswerID, input.AttemptID)
+	answers, err := s.repo.GetUserAnswers(ctx, attempt.ID)
 
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return err
+		return models.CurrentAttempt{}, err
 	}
 
-	if answerExists {
-		return http_errors.ErrPermissionDenied
-	}
+	questions, err := s.getVersionQuestions(ctx, quizID, attempt.VersionID)
 
-	if err := s.repo.SaveUserAnswer(ctx, userID, input.QuestionID, input.AnswerID, input.AttemptID, input.AnswerText); err != nil {
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return err
+		return models.CurrentAttempt{}, err
 	}
 
-	if question.Type == "choice" {
-		if input.AnswerID == 0 {
-			return http_errors.ErrWrongArgument
-		}
+	maskOrderingAnswers(questions, answers, attempt.ID)
 
-		err := s.ProcessChoiceAnswer(ctx, question.ID, userID, input)
+	return models.CurrentAttempt{
+		Attempt: newAttempt(attempt),
+		Answers: answers,
+	}, nil
+}
 
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
+func (s *Service) checkAttemptPolicy(ctx context.Context, userID int, quiz models.Quiz) error {
+	if quiz.MaxAttempts > 0 {
+		count, err := s.repo.CountAttempts(ctx, userID, quiz.ID)
 
+		if err != nil {
 			return err
 		}
-	} else {
-		if input.AnswerText == "" {
-			return http_errors.ErrWrongArgument
+
+		if count >= quiz.MaxAttempts {
+			return http_errors.ErrAttemptsExceeded
 		}
+	}
 
-		err := s.ProcessInputAnswer(ctx, question.ID, userID, input)
+	if quiz.AttemptCooldown > 0 {
+		last, err := s.repo.GetLastAttempt(ctx, userID, quiz.ID)
 
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
 
+		if err != nil {
 			return err
 		}
+
+		finishedAt := last.CreatedAt
+
+		if last.SubmittedAt != nil {
+			finishedAt = *last.SubmittedAt
+		}
+
+		if finishedAt.Add(time.Duration(quiz.AttemptCooldown) * time.Second).After(time.Now()) {
+			return http_errors.ErrAttemptCooldown
+		}
 	}
 
 	return nil
 }
 
-func (s *Service) ProcessChoiceAnswer(ctx context.Context, questionID, userID int, input domain.UserAnswer) error {
-	ctx, span := s.tracer.Start(ctx, "resultService.ProcessChoiceAnswer")
+func (s *Service) SaveUserAnswer(ctx context.Context, userID, quizID int, input domain.UserAnswer) (models.Attempt, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.SaveUserAnswer")
 	defer span.End()
 
-	answer, err := s.answerRepo.GetByID(ctx, input.AnswerID)
+	attempt, err := s.CheckPermissions(ctx, userID, quizID, input)
+
+	if err != nil {
+		return models.Attempt{}, err
+	}
+
+	questions, err := s.getVersionQuestions(ctx, quizID, attempt.VersionID)
 
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return err
+		return models.Attempt{}, err
 	}
 
-	if answer.QuestionID != questionID {
-		return http_errors.ErrWrongArgument
+	var question models.QuestionWithAnswers
+
+	for _, item := range questions {
+		if item.ID == input.QuestionID {
+			question = item
+		}
 	}
 
-	if answer.IsCorrect {
-		err := s.repo.UpdateResult(ctx, input.AttemptID, userID, 1)
+	if question.ID == 0 {
+		return models.Attempt{}, http_errors.ErrWrongArgument
+	}
 
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
+	pool, err := s.getAttemptPool(ctx, attempt.ID)
 
-			return err
-		}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Attempt{}, err
 	}
 
-	return nil
-}
+	if pool != nil && !pool[question.ID] {
+		return models.Attempt{}, http_errors.ErrWrongArgument
+	}
 
-func (s *Service) ProcessInputAnswer(ctx context.Context, questionID, userID int, input domain.UserAnswer) error {
-	ctx, span := s.tracer.Start(ctx, "resultService.ProcessInputAnswer")
-	defer span.End()
+	var answers []models.UserAnswer
 
-	correctAnswers, err := s.answerRepo.GetAnswersByQuestionID(ctx, questionID)
+	switch question.Type {
+	case types.Choice, types.TrueFalse:
+		answers, err = s.ProcessChoiceAnswer(ctx, question, input)
+	case types.Multiple:
+		answers, err = s.ProcessMultipleAnswer(ctx, question, input)
+	case types.Ordering:
+		answers, err = s.ProcessOrderingAnswer(ctx, question, input)
+	case types.Matching:
+		answers, err = s.ProcessMatchingAnswer(ctx, question, input)
+	case types.Numeric:
+		answers, err = s.ProcessNumericAnswer(ctx, question, input)
+	case types.Essay:
+		answers, err = s.ProcessEssayAnswer(ctx, question, input)
+	default:
+		answers, err = s.ProcessInputAnswer(ctx, question, input)
+	}
 
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return err
+		return models.Attempt{}, err
 	}
 
-	for _, ans := range correctAnswers {
-		if strings.EqualFold(ans.Text, input.AnswerText) {
-			err := s.repo.UpdateResult(ctx, input.AttemptID, userID, 1)
+	for i := range answers {
+		answers[i].UserID = userID
+		answers[i].QuestionID = question.ID
+		answers[i].ResultID = attempt.ID
+	}
 
-			if err != nil {
-				span.RecordError(err)
-				span.SetStatus(codes.Error, err.Error())
+	if err := s.repo.SaveUserAnswers(ctx, attempt.ID, question.ID, answers); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 
-				return err
-			}
+		return models.Attempt{}, err
+	}
+
+	return newAttempt(attempt), nil
+}
+
+func (s *Service) ProcessChoiceAnswer(ctx context.Context, question models.QuestionWithAnswers, input domain.UserAnswer) ([]models.UserAnswer, error) {
+	_, span := s.tracer.Start(ctx, "resultService.ProcessChoiceAnswer")
+	defer span.End()
 
-			break
+	if input.AnswerID == 0 {
+		return nil, http_errors.ErrWrongArgument
+	}
+
+	for _, answer := range question.Answers {
+		if answer.ID == input.AnswerID {
+			return []models.UserAnswer{{AnswerID: answer.ID}}, nil
 		}
 	}
 
-	return nil
+	return nil, http_errors.ErrWrongArgument
+}
+
+func (s *Service) ProcessInputAnswer(ctx context.Context, question models.QuestionWithAnswers, input domain.UserAnswer) ([]models.UserAnswer, error) {
+	_, span := s.tracer.Start(ctx, "resultService.ProcessInputAnswer")
+	defer span.End()
+
+	if input.AnswerText == "" {
+		return nil, http_errors.ErrWrongArgument
+	}
+
+	return []models.UserAnswer{{Text: input.AnswerText}}, nil
+}
+
+func (s *Service) ProcessMultipleAnswer(ctx context.Context, question models.QuestionWithAnswers, input domain.UserAnswer) ([]models.UserAnswer, error) {
+	_, span := s.tracer.Start(ctx, "resultService.ProcessMultipleAnswer")
+	defer span.End()
+
+	if len(input.AnswerIDs) == 0 {
+		return nil, http_errors.ErrWrongArgument
+	}
+
+	validIDs := make(map[int]bool, len(question.Answers))
+
+	for _, answer := range question.Answers {
+		validIDs[answer.ID] = true
+	}
+
+	answers := make([]models.UserAnswer, 0, len(input.AnswerIDs))
+	selected := make(map[int]bool, len(input.AnswerIDs))
+
+	for _, answerID := range input.AnswerIDs {
+		if !validIDs[answerID] || selected[answerID] {
+			return nil, http_errors.ErrWrongArgument
+		}
+
+		selected[answerID] = true
+		answers = append(answers, models.UserAnswer{AnswerID: answerID})
+	}
+
+	return answers, nil
+}
+
+// ProcessOrderingAnswer expects every answer of the question exactly once, in the
+// order chosen by the user. The rows are saved in that order.
+func (s *Service) ProcessOrderingAnswer(ctx context.Context, question models.QuestionWithAnswers, input domain.UserAnswer) ([]models.UserAnswer, error) {
+	_, span := s.tracer.Start(ctx, "resultService.ProcessOrderingAnswer")
+	defer span.End()
+
+	if len(input.AnswerIDs) != len(question.Answers) {
+		return nil, http_errors.ErrWrongArgument
+	}
+
+	input.AnswerIDs = types.UnmaskIDs(question.Answers, input.AnswerIDs, input.AttemptID, question.ID)
+
+	return s.ProcessMultipleAnswer(ctx, question, input)
+}
+
+// ProcessMatchingAnswer stores each pair as the left answer id with the chosen match as text.
+func (s *Service) ProcessMatchingAnswer(ctx context.Context, question models.QuestionWithAnswers, input domain.UserAnswer) ([]models.UserAnswer, error) {
+	_, span := s.tracer.Start(ctx, "resultService.ProcessMatchingAnswer")
+	defer span.End()
+
+	if len(input.Pairs) == 0 {
+		return nil, http_errors.ErrWrongArgument
+	}
+
+	validIDs := make(map[int]bool, len(question.Answers))
+	matches := make(map[string]bool, len(question.Answers))
+
+	for _, answer := range question.Answers {
+		validIDs[answer.ID] = true
+		matches[answer.MatchText] = true
+	}
+
+	answers := make([]models.UserAnswer, 0, len(input.Pairs))
+	paired := make(map[int]bool, len(input.Pairs))
+
+	for _, pair := range input.Pairs {
+		if !validIDs[pair.AnswerID] || paired[pair.AnswerID] || !matches[pair.MatchText] {
+			return nil, http_errors.ErrWrongArgument
+		}
+
+		paired[pair.AnswerID] = true
+		answers = append(answers, models.UserAnswer{AnswerID: pair.AnswerID, Text: pair.MatchText})
+	}
+
+	return answers, nil
+}
+
+func (s *Service) ProcessNumericAnswer(ctx context.Context, question models.QuestionWithAnswers, input domain.UserAnswer) ([]models.UserAnswer, error) {
+	_, span := s.tracer.Start(ctx, "resultService.ProcessNumericAnswer")
+	defer span.End()
+
+	if _, err := types.ParseNumber(input.AnswerText); err != nil {
+		return nil, http_errors.ErrWrongArgument
+	}
+
+	return []models.UserAnswer{{Text: strings.TrimSpace(input.AnswerText)}}, nil
+}
+
+// ProcessEssayAnswer stores the text for the quiz owner to grade by hand.
+func (s *Service) ProcessEssayAnswer(ctx context.Context, question models.QuestionWithAnswers, input domain.UserAnswer) ([]models.UserAnswer, error) {
+	_, span := s.tracer.Start(ctx, "resultService.ProcessEssayAnswer")
+	defer span.End()
+
+	text := strings.TrimSpace(input.AnswerText)
+
+	if text == "" || utf8.RuneCountInString(text) > maxEssayLength {
+		return nil, http_errors.ErrWrongArgument
+	}
+
+	return []models.UserAnswer{{Text: text, NeedsReview: true}}, nil
 }
 
-func (s *Service) CheckPermissions(ctx context.Context, userID, quizID int, input domain.UserAnswer) error {
+func (s *Service) CheckPermissions(ctx context.Context, userID, quizID int, input domain.UserAnswer) (models.Result, error) {
 	if _, err := s.quizRepo.GetByID(ctx, quizID); err != nil {
-		return err
+		return models.Result{}, err
 	}
 
-	attempt, err := s.repo.GetByID(ctx, input.AttemptID)
+	return s.checkAttempt(ctx, userID, quizID, input.AttemptID)
+}
+
+func (s *Service) checkAttempt(ctx context.Context, userID, quizID, attemptID int) (models.Result, error) {
+	attempt, err := s.repo.GetByID(ctx, attemptID)
 
 	if err != nil {
-		return err
+		return models.Result{}, err
 	}
 
-	if attempt.UserID != userID || attempt.IsCompleted {
-		return http_errors.ErrPermissionDenied
+	if attempt.UserID != userID || attempt.QuizID != quizID || attempt.IsCompleted {
+		return models.Result{}, http_errors.ErrPermissionDenied
 	}
 
-	return nil
+	if attempt.ExpiresAt != nil && attempt.ExpiresAt.Before(time.Now()) {
+		return models.Result{}, http_errors.ErrAttemptExpired
+	}
+
+	return attempt, nil
 }
 
-func (s *Service) GetResultsByQuizID(ctx context.Context, quizID int) ([]models.UsersResult, error) {
+func (s *Service) GetResultsByQuizID(ctx context.Context, userID, quizID int, accessCode string) ([]models.UsersResult, error) {
 	ctx, span := s.tracer.Start(ctx, "resultService.GetResultsByQuizID")
 	defer span.End()
 
-	return s.repo.GetByQuizID(ctx, quizID)
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	if err := visibility.Check(ctx, s.quizRepo, quiz, userID, accessCode); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByQuizID(ctx, quizID, quiz.ScorePolicy)
 }
 
-func (s *Service) SubmitResult(ctx context.Context, userID, quizID int, input domain.SubmitResult) (models.UsersResult, error) {
-	ctx, span := s.tracer.Start(ctx, "resultService.SubmitResult")
+// GetBroadcastResults skips the visibility check: the results only go to the quiz
+// room, which connections join after passing it in GetResultsByQuizID.
+func (s *Service) GetBroadcastResults(ctx context.Context, quizID int) ([]models.UsersResult, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.GetBroadcastResults")
 	defer span.End()
 
-	if _, err := s.quizRepo.GetByID(ctx, quizID); err != nil {
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return models.UsersResult{}, err
+		return nil, err
+	}
+
+	return s.repo.GetByQuizID(ctx, quizID, quiz.ScorePolicy)
+}
+
+func (s *Service) GetLeaderboard(ctx context.Context, userID, quizID int, accessCode, period string, page, size int) (models.Leaderboard, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.GetLeaderboard")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Leaderboard{}, err
+	}
+
+	if err := visibility.Check(ctx, s.quizRepo, quiz, userID, accessCode); err != nil {
+		return models.Leaderboard{}, err
+	}
+
+	leaderboard, err := s.repo.GetLeaderboard(ctx, quizID, quiz.ScorePolicy, period, page, size)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Leaderboard{}, err
+	}
+
+	me, err := s.repo.GetLeaderboardRank(ctx, quizID, userID, quiz.ScorePolicy, period)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Leaderboard{}, err
+	}
+
+	if err == nil {
+		leaderboard.Me = &me
+	}
+
+	return leaderboard, nil
+}
+
+func (s *Service) GetAnalytics(ctx context.Context, userID, quizID int) (models.QuizAnalytics, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.GetAnalytics")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.QuizAnalytics{}, err
+	}
+
+	if quiz.UserID != userID {
+		return models.QuizAnalytics{}, http_errors.ErrPermissionDenied
+	}
+
+	attempts, err := s.repo.GetAttemptsByQuizID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.QuizAnalytics{}, err
+	}
+
+	questions, questionsByVersion, err := s.getAttemptsQuestions(ctx, quiz, attempts)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.QuizAnalytics{}, err
+	}
+
+	userAnswers, err := s.repo.GetUserAnswersByQuizID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.QuizAnalytics{}, err
+	}
+
+	resultQuestions, err := s.repo.GetResultQuestionsByQuizID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.QuizAnalytics{}, err
+	}
+
+	return buildAnalytics(attempts, questions, questionsByVersion, userAnswers, resultQuestions), nil
+}
+
+func (s *Service) ExportResults(ctx context.Context, userID, quizID int, format string, w io.Writer) error {
+	ctx, span := s.tracer.Start(ctx, "resultService.ExportResults")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if quiz.UserID != userID {
+		return http_errors.ErrPermissionDenied
+	}
+
+	return s.exportResults(ctx, quiz, format, w)
+}
+
+func (s *Service) ExportResultsAdmin(ctx context.Context, quizID int, format string, w io.Writer) error {
+	ctx, span := s.tracer.Start(ctx, "resultService.ExportResultsAdmin")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return s.exportResults(ctx, quiz, format, w)
+}
+
+func (s *Service) exportResults(ctx context.Context, quiz models.Quiz, format string, w io.Writer) error {
+	attempts, err := s.repo.GetAttemptsByQuizID(ctx, quiz.ID)
+
+	if err != nil {
+		return err
+	}
+
+	questions, questionsByVersion, err := s.getAttemptsQuestions(ctx, quiz, attempts)
+
+	if err != nil {
+		return err
+	}
+
+	versionQuestions := indexVersionQuestions(questionsByVersion)
+
+	var writer export.Writer
+
+	if format == "xlsx" {
+		writer, err = export.NewXLSXWriter(w)
+
+		if err != nil {
+			return err
+		}
+	} else {
+		writer = export.NewCSVWriter(w)
+	}
+
+	header := []string{"User", "Email", "Score", "Max score", "Percentage", "Started at", "Submitted at", "Status"}
+
+	for i, question := range questions {
+		header = append(header, fmt.Sprintf("%d. %s", i+1, question.Title))
+	}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	err = s.repo.ExportResults(ctx, quiz.ID, func(result models.ResultExport) error {
+		row := []string{
+			result.Username,
+			result.Email,
+			strconv.FormatFloat(result.Score, 'f', -1, 64),
+			strconv.FormatFloat(result.MaxScore, 'f', -1, 64),
+			strconv.FormatFloat(result.Percentage, 'f', 2, 64),
+			result.CreatedAt.Format(time.DateTime),
+			"",
+			result.Status,
+		}
+
+		if result.SubmittedAt != nil {
+			row[6] = result.SubmittedAt.Format(time.DateTime)
+		}
+
+		for _, question := range questions {
+			answers := make([]string, 0, len(result.Answers[question.ID]))
+
+			for _, answer := range result.Answers[question.ID] {
+				answers = append(answers, exportAnswer(versionQuestions[versionKey(result.VersionID)][question.ID], answer))
+			}
+
+			row = append(row, strings.Join(answers, "; "))
+		}
+
+		return writer.Write(row)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (s *Service) GetResult(ctx context.Context, userID, quizID, resultID int) (models.ResultReview, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.GetResult")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.ResultReview{}, err
+	}
+
+	result, err := s.repo.GetByID(ctx, resultID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.ResultReview{}, err
+	}
+
+	if result.QuizID != quizID || !result.IsCompleted || (result.UserID != userID && quiz.UserID != userID) {
+		return models.ResultReview{}, http_errors.ErrPermissionDenied
+	}
+
+	questions, answersByQuestion, err := s.getAttemptAnswers(ctx, result)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.ResultReview{}, err
+	}
+
+	showAnswers := quiz.ShowAnswers || quiz.UserID == userID
+
+	review := models.ResultReview{
+		Result:    result,
+		Questions: make([]models.QuestionReview, 0, len(questions)),
+	}
+
+	for _, question := range questions {
+		answers, ok := answersByQuestion[question.ID]
+
+		if !ok {
+			answers = []models.UserAnswer{}
+		}
+
+		score := gradeQuestion(question, answers)
+
+		questionReview := models.QuestionReview{
+			ID:          question.ID,
+			Title:       question.Title,
+			TitleHTML:   markdown.Render(question.Title),
+			Image:       question.Image,
+			Audio:       question.Audio,
+			Type:        question.Type,
+			Points:      question.Points,
+			Earned:      score * float64(question.Points),
+			IsCorrect:   score == 1,
+			UserAnswers: answers,
+		}
+
+		if showAnswers {
+			questionReview.CorrectAnswers = correctAnswers(question)
+			questionReview.Explanation = question.Explanation
+			questionReview.ExplanationHTML = markdown.Render(question.Explanation)
+
+			content.Answers(questionReview.CorrectAnswers)
+		}
+
+		review.Questions = append(review.Questions, questionReview)
+	}
+
+	return review, nil
+}
+
+func (s *Service) SubmitResult(ctx context.Context, userID, quizID int, input domain.SubmitResult) (models.UsersResult, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.SubmitResult")
+	defer span.End()
+
+	if _, err := s.quizRepo.GetByID(ctx, quizID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.UsersResult{}, err
+	}
+
+	attempt, err := s.checkAttempt(ctx, userID, quizID, input.AttemptID)
+
+	if err != nil {
+		return models.UsersResult{}, err
+	}
+
+	return s.submitResult(ctx, attempt)
+}
+
+func (s *Service) SubmitExpiredResults(ctx context.Context) ([]models.Result, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.SubmitExpiredResults")
+	defer span.End()
+
+	submitted := make([]models.Result, 0)
+
+	err := s.repo.LockExpired(ctx, func() error {
+		results, err := s.repo.GetExpired(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			_, err := s.submitResult(ctx, result)
+
+			if errors.Is(err, http_errors.ErrAlreadySubmitted) {
+				continue
+			}
+
+			if err != nil {
+				s.log.Infof("error while submit expired result %d: %s", result.ID, err)
+
+				span.RecordError(err)
+
+				continue
+			}
+
+			submitted = append(submitted, result)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return submitted, nil
+}
+
+func (s *Service) submitResult(ctx context.Context, attempt models.Result) (models.UsersResult, error) {
+	questions, answersByQuestion, err := s.getAttemptAnswers(ctx, attempt)
+
+	if err != nil {
+		return models.UsersResult{}, err
+	}
+
+	score, maxScore := scoreAttempt(questions, answersByQuestion)
+
+	return s.repo.SubmitResult(ctx, attempt.UserID, attempt.ID, score, maxScore, needsReview(answersByQuestion))
+}
+
+func (s *Service) GetGradingQueue(ctx context.Context, userID, quizID int) ([]models.GradingItem, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.GetGradingQueue")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	if quiz.UserID != userID {
+		return nil, http_errors.ErrPermissionDenied
+	}
+
+	items, err := s.repo.GetGradingQueue(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	// Attempts keep the questions of the version they were started on,
+	// so titles and points come from that version.
+	versions := make(map[int][]models.QuestionWithAnswers)
+
+	for i, item := range items {
+		key := 0
+
+		if item.VersionID != nil {
+			key = *item.VersionID
+		}
+
+		questions, ok := versions[key]
+
+		if !ok {
+			questions, err = s.getVersionQuestions(ctx, quizID, item.VersionID)
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+
+				return nil, err
+			}
+
+			versions[key] = questions
+		}
+
+		if question, ok := findQuestion(questions, item.QuestionID); ok {
+			items[i].QuestionTitle = question.Title
+			items[i].MaxPoints = question.Points
+		}
+	}
+
+	return items, nil
+}
+
+func (s *Service) GradeAnswer(ctx context.Context, userID, quizID, answerID int, input domain.GradeAnswer) error {
+	ctx, span := s.tracer.Start(ctx, "resultService.GradeAnswer")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if quiz.UserID != userID {
+		return http_errors.ErrPermissionDenied
+	}
+
+	answer, err := s.repo.GetUserAnswerByID(ctx, answerID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	attempt, err := s.repo.GetByID(ctx, answer.ResultID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if attempt.QuizID != quizID {
+		return http_errors.ErrPermissionDenied
+	}
+
+	if !attempt.PendingReview || !answer.NeedsReview {
+		return fmt.Errorf("%w: answer is not waiting for review", http_errors.ErrWrongArgument)
+	}
+
+	questions, err := s.getVersionQuestions(ctx, quizID, attempt.VersionID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	question, ok := findQuestion(questions, answer.QuestionID)
+
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	if input.Points > float64(question.Points) {
+		return fmt.Errorf("%w: points exceed the question points (%d)", http_errors.ErrWrongArgument, question.Points)
+	}
+
+	if err := s.repo.GradeAnswer(ctx, answerID, input.Points, strings.TrimSpace(input.Feedback)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+// FinalizeResult recomputes the score of a graded attempt, publishes it and lets the taker know.
+func (s *Service) FinalizeResult(ctx context.Context, userID, quizID, resultID int) (models.UsersResult, error) {
+	ctx, span := s.tracer.Start(ctx, "resultService.FinalizeResult")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, quizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.UsersResult{}, err
+	}
+
+	if quiz.UserID != userID {
+		return models.UsersResult{}, http_errors.ErrPermissionDenied
+	}
+
+	attempt, err := s.repo.GetByID(ctx, resultID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.UsersResult{}, err
+	}
+
+	if attempt.QuizID != quizID {
+		return models.UsersResult{}, http_errors.ErrPermissionDenied
+	}
+
+	if !attempt.PendingReview {
+		return models.UsersResult{}, fmt.Errorf("%w: result is not waiting for review", http_errors.ErrWrongArgument)
+	}
+
+	ungraded, err := s.repo.CountUngraded(ctx, resultID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.UsersResult{}, err
+	}
+
+	if ungraded > 0 {
+		return models.UsersResult{}, fmt.Errorf("%w: %d answers are not graded yet", http_errors.ErrWrongArgument, ungraded)
+	}
+
+	questions, answersByQuestion, err := s.getAttemptAnswers(ctx, attempt)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.UsersResult{}, err
+	}
+
+	score, maxScore := scoreAttempt(questions, answersByQuestion)
+
+	result, err := s.repo.FinalizeResult(ctx, resultID, score, maxScore)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.UsersResult{}, err
+	}
+
+	// The grade is saved at this point, a failed notification should not fail the request.
+	if err := s.notifyGraded(ctx, quizID, resultID); err != nil {
+		s.log.Infof("error while notify about graded result: %s", err)
+	}
+
+	return result, nil
+}
+
+func (s *Service) notifyGraded(ctx context.Context, quizID, resultID int) error {
+	user, err := s.repo.GetResultUser(ctx, resultID)
+
+	if err != nil {
+		return err
+	}
+
+	email := domain.Email{
+		Type:     mail.ResultGradedType,
+		To:       user.Email,
+		Username: user.Username,
+		Code:     fmt.Sprintf("%d/results/%d", quizID, resultID),
+	}
+
+	bytes, err := json.Marshal(&email)
+
+	if err != nil {
+		return err
+	}
+
+	return s.producer.PublishMessage(ctx, bytes)
+}
+
+func (s *Service) getAttemptAnswers(ctx context.Context, attempt models.Result) ([]models.QuestionWithAnswers, map[int][]models.UserAnswer, error) {
+	questions, err := s.getVersionQuestions(ctx, attempt.QuizID, attempt.VersionID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pool, err := s.getAttemptPool(ctx, attempt.ID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if pool != nil {
+		drawn := make([]models.QuestionWithAnswers, 0, len(pool))
+
+		for _, question := range questions {
+			if pool[question.ID] {
+				drawn = append(drawn, question)
+			}
+		}
+
+		questions = drawn
+	}
+
+	userAnswers, err := s.repo.GetUserAnswers(ctx, attempt.ID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	answersByQuestion := make(map[int][]models.UserAnswer)
+
+	for _, answer := range userAnswers {
+		answersByQuestion[answer.QuestionID] = append(answersByQuestion[answer.QuestionID], answer)
+	}
+
+	return questions, answersByQuestion, nil
+}
+
+// getVersionQuestions returns the questions of a published quiz version. Attempts
+// started before versioning have no version and fall back to the current questions.
+func (s *Service) getVersionQuestions(ctx context.Context, quizID int, versionID *int) ([]models.QuestionWithAnswers, error) {
+	if versionID == nil {
+		return s.questionRepo.GetQuestionsAuthor(ctx, quizID)
+	}
+
+	version, err := s.quizRepo.GetVersion(ctx, *versionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return version.Questions, nil
+}
+
+// maskOrderingAnswers gives saved ordering answers the masked ids the taker was
+// shown, see types.HideKey.
+func maskOrderingAnswers(questions []models.QuestionWithAnswers, answers []models.UserAnswer, attemptID int) {
+	for _, question := range questions {
+		if question.Type != types.Ordering {
+			continue
+		}
+
+		ids := make([]int, 0, len(question.Answers))
+
+		for _, answer := range question.Answers {
+			ids = append(ids, answer.ID)
+		}
+
+		masks := types.MaskIDs(ids, attemptID, question.ID)
+
+		for i := range answers {
+			if answers[i].QuestionID == question.ID {
+				answers[i].AnswerID = masks[answers[i].AnswerID]
+			}
+		}
+	}
+}
+
+// versionKey identifies the quiz version of an attempt, 0 for attempts started
+// before versioning.
+func versionKey(versionID *int) int {
+	if versionID == nil {
+		return 0
+	}
+
+	return *versionID
+}
+
+// getAttemptsQuestions loads the questions of the current quiz version and of
+// every version the attempts were taken on, keyed by versionKey. The first
+// result lists the questions to report on: the current ones in order, then the
+// ones only older versions had.
+func (s *Service) getAttemptsQuestions(ctx context.Context, quiz models.Quiz, attempts []models.Result) ([]models.QuestionWithAnswers, map[int][]models.QuestionWithAnswers, error) {
+	keys := []int{versionKey(quiz.VersionID)}
+	seenKeys := map[int]bool{keys[0]: true}
+	older := make([]int, 0)
+
+	for _, attempt := range attempts {
+		key := versionKey(attempt.VersionID)
+
+		if !seenKeys[key] {
+			seenKeys[key] = true
+			older = append(older, key)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(older)))
+	keys = append(keys, older...)
+
+	questions := make([]models.QuestionWithAnswers, 0)
+	questionsByVersion := make(map[int][]models.QuestionWithAnswers, len(keys))
+	seenQuestions := make(map[int]bool)
+
+	for _, key := range keys {
+		var versionID *int
+
+		if key != 0 {
+			versionID = &key
+		}
+
+		versionQuestions, err := s.getVersionQuestions(ctx, quiz.ID, versionID)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		questionsByVersion[key] = versionQuestions
+
+		for _, question := range versionQuestions {
+			if !seenQuestions[question.ID] {
+				seenQuestions[question.ID] = true
+				questions = append(questions, question)
+			}
+		}
+	}
+
+	return questions, questionsByVersion, nil
+}
+
+func indexVersionQuestions(questionsByVersion map[int][]models.QuestionWithAnswers) map[int]map[int]models.QuestionWithAnswers {
+	index := make(map[int]map[int]models.QuestionWithAnswers, len(questionsByVersion))
+
+	for key, questions := range questionsByVersion {
+		index[key] = make(map[int]models.QuestionWithAnswers, len(questions))
+
+		for _, question := range questions {
+			index[key][question.ID] = question
+		}
+	}
+
+	return index
+}
+
+// exportAnswer formats a saved answer with the answer texts of the version the
+// attempt was taken on.
+func exportAnswer(question models.QuestionWithAnswers, answer models.UserAnswer) string {
+	for _, item := range question.Answers {
+		if item.ID != answer.AnswerID {
+			continue
+		}
+
+		if answer.Text != "" {
+			return item.Text + " -> " + answer.Text
+		}
+
+		return item.Text
+	}
+
+	return answer.Text
+}
+
+// scoreAttempt returns the points earned in an attempt and the points it was worth.
+func scoreAttempt(questions []models.QuestionWithAnswers, answersByQuestion map[int][]models.UserAnswer) (float64, float64) {
+	var score, maxScore float64
+
+	for _, question := range questions {
+		score += gradeQuestion(question, answersByQuestion[question.ID]) * float64(question.Points)
+		maxScore += float64(question.Points)
+	}
+
+	return score, maxScore
+}
+
+// needsReview reports whether any answer of the attempt is waiting to be graded by hand.
+func needsReview(answersByQuestion map[int][]models.UserAnswer) bool {
+	for _, answers := range answersByQuestion {
+		for _, answer := range answers {
+			if answer.NeedsReview && answer.Points == nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func findQuestion(questions []models.QuestionWithAnswers, questionID int) (models.QuestionWithAnswers, bool) {
+	for _, question := range questions {
+		if question.ID == questionID {
+			return question, true
+		}
+	}
+
+	return models.QuestionWithAnswers{}, false
+}
+
+func (s *Service) GradeQuestion(question models.QuestionWithAnswers, answers []models.UserAnswer) float64 {
+	return gradeQuestion(question, answers)
+}
+
+// getAttemptPool returns the questions drawn for the attempt, or nil if the attempt uses the whole quiz.
+func (s *Service) getAttemptPool(ctx context.Context, attemptID int) (map[int]bool, error) {
+	questionIDs, err := s.repo.GetResultQuestions(ctx, attemptID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	pool := make(map[int]bool, len(questionIDs))
+
+	for _, questionID := range questionIDs {
+		pool[questionID] = true
+	}
+
+	return pool, nil
+}
+
+// drawQuestions picks size random questions. With byTag the picks are split between
+// tags in proportion to how many questions each tag has.
+func drawQuestions(questions []models.QuestionWithAnswers, size int, byTag bool) []int {
+	groups := make(map[string][]int)
+	tags := make([]string, 0)
+
+	for _, question := range questions {
+		tag := ""
+
+		if byTag {
+			tag = question.Tag
+		}
+
+		if _, ok := groups[tag]; !ok {
+			tags = append(tags, tag)
+		}
+
+		groups[tag] = append(groups[tag], question.ID)
+	}
+
+	size = min(size, len(questions))
+	quotas := make(map[string]int, len(tags))
+	left := size
+
+	for _, tag := range tags {
+		quotas[tag] = size * len(groups[tag]) / len(questions)
+		left -= quotas[tag]
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return size*len(groups[tags[i]])%len(questions) > size*len(groups[tags[j]])%len(questions)
+	})
+
+	for i := 0; i < left; i++ {
+		quotas[tags[i]]++
+	}
+
+	drawn := make([]int, 0, size)
+
+	for _, tag := range tags {
+		ids := groups[tag]
+
+		rand.Shuffle(len(ids), func(i, j int) {
+			ids[i], ids[j] = ids[j], ids[i]
+		})
+
+		drawn = append(drawn, ids[:quotas[tag]]...)
+	}
+
+	return drawn
+}
+
+// gradeQuestion returns the share of the question points earned by the given answers.
+func gradeQuestion(question models.QuestionWithAnswers, userAnswers []models.UserAnswer) float64 {
+	switch question.Type {
+	case types.Choice, types.TrueFalse:
+		for _, userAnswer := range userAnswers {
+			for _, answer := range question.Answers {
+				if answer.ID == userAnswer.AnswerID && answer.IsCorrect {
+					return 1
+				}
+			}
+		}
+
+		return 0
+	case types.Multiple:
+		selected := make(map[int]bool, len(userAnswers))
+
+		for _, userAnswer := range userAnswers {
+			selected[userAnswer.AnswerID] = true
+		}
+
+		return multipleChoiceScore(question.Scoring, question.Answers, selected)
+	case types.Ordering:
+		return orderingScore(question.Scoring, question.Answers, userAnswers)
+	case types.Matching:
+		return matchingScore(question.Scoring, question.Answers, userAnswers)
+	case types.Numeric:
+		for _, userAnswer := range userAnswers {
+			if types.NumericScore(question.Answers, userAnswer.Text) > 0 {
+				return 1
+			}
+		}
+
+		return 0
+	case types.Essay:
+		if question.Points == 0 {
+			return 0
+		}
+
+		var points float64
+
+		for _, userAnswer := range userAnswers {
+			if userAnswer.Points != nil {
+				points += *userAnswer.Points
+			}
+		}
+
+		return math.Min(points/float64(question.Points), 1)
+	default:
+		for _, userAnswer := range userAnswers {
+			if match.Input(question, userAnswer.Text) {
+				return 1
+			}
+		}
+
+		return 0
+	}
+}
+
+func correctAnswers(question models.QuestionWithAnswers) []models.Answer {
+	switch question.Type {
+	case types.Ordering:
+		return sortedAnswers(question.Answers)
+	case types.Input, types.Numeric, types.Matching, types.Essay:
+		return question.Answers
+	}
+
+	answers := make([]models.Answer, 0)
+
+	for _, answer := range question.Answers {
+		if answer.IsCorrect {
+			answers = append(answers, answer)
+		}
+	}
+
+	return answers
+}
+
+// multipleChoiceScore returns the share of the question points earned for a multi-select question:
+// all_or_nothing requires exactly the correct set, proportional gives credit for
+// correct picks relative to the larger of the correct set and the selection, and
+// penalty subtracts every wrong pick from the correct ones.
+func multipleChoiceScore(scoring string, answers []models.Answer, selected map[int]bool) float64 {
+	var correct, correctPicked, wrongPicked int
+
+	for _, answer := range answers {
+		if answer.IsCorrect {
+			correct++
+		}
+
+		if selected[answer.ID] {
+			if answer.IsCorrect {
+				correctPicked++
+			} else {
+				wrongPicked++
+			}
+		}
+	}
+
+	if correct == 0 {
+		return 0
+	}
+
+	switch scoring {
+	case "proportional":
+		return float64(correctPicked) / float64(max(correct, correctPicked+wrongPicked))
+	case "penalty":
+		return float64(max(correctPicked-wrongPicked, 0)) / float64(correct)
+	default:
+		if correctPicked == correct && wrongPicked == 0 {
+			return 1
+		}
+
+		return 0
+	}
+}
+
+// orderingScore compares the submitted sequence with the answers sorted by order_id.
+// Proportional scoring gives credit for every answer in its correct position.
+func orderingScore(scoring string, answers []models.Answer, userAnswers []models.UserAnswer) float64 {
+	if len(answers) == 0 {
+		return 0
+	}
+
+	correct := 0
+
+	for i, answer := range sortedAnswers(answers) {
+		if i < len(userAnswers) && userAnswers[i].AnswerID == answer.ID {
+			correct++
+		}
+	}
+
+	return partialScore(scoring, correct, len(answers))
+}
+
+// matchingScore counts the pairs whose chosen match is the one stored on the answer.
+func matchingScore(scoring string, answers []models.Answer, userAnswers []models.UserAnswer) float64 {
+	if len(answers) == 0 {
+		return 0
+	}
+
+	matches := make(map[int]string, len(answers))
+
+	for _, answer := range answers {
+		matches[answer.ID] = answer.MatchText
+	}
+
+	correct := 0
+
+	for _, userAnswer := range userAnswers {
+		if match, ok := matches[userAnswer.AnswerID]; ok && match == userAnswer.Text {
+			correct++
+		}
+	}
+
+	return partialScore(scoring, correct, len(answers))
+}
+
+func partialScore(scoring string, correct, total int) float64 {
+	if scoring == "proportional" {
+		return float64(correct) / float64(total)
+	}
+
+	if correct == total {
+		return 1
+	}
+
+	return 0
+}
+
+func sortedAnswers(answers []models.Answer) []models.Answer {
+	sorted := make([]models.Answer, len(answers))
+	copy(sorted, answers)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OrderID < sorted[j].OrderID
+	})
+
+	return sorted
+}
+
+func newAttempt(result models.Result) models.Attempt {
+	attempt := models.Attempt{
+		ID:        result.ID,
+		ExpiresAt: result.ExpiresAt,
+	}
+
+	if result.ExpiresAt != nil {
+		timeLeft := max(int(time.Until(*result.ExpiresAt).Seconds()), 0)
+		attempt.TimeLeft = &timeLeft
+	}
+
+	return attempt
+}
+
+// buildAnalytics aggregates completed attempts into quiz and per-question statistics.
+// The discrimination index compares the share of correct answers between the top and
+// bottom 27% of attempts ranked by percentage.
+// buildAnalytics reports on questions, grading every attempt against the
+// questions of the version it was taken on.
+func buildAnalytics(attempts []models.Result, questions []models.QuestionWithAnswers, questionsByVersion map[int][]models.QuestionWithAnswers, userAnswers []models.UserAnswer, resultQuestions []models.ResultQuestion) models.QuizAnalytics {
+	analytics := models.QuizAnalytics{
+		Started:      len(attempts),
+		Distribution: make([]models.ScoreBucket, 0, 10),
+		Questions:    make([]models.QuestionAnalytics, 0, len(questions)),
+	}
+
+	for i := 0; i < 10; i++ {
+		analytics.Distribution = append(analytics.Distribution, models.ScoreBucket{From: i * 10, To: i*10 + 10})
+	}
+
+	completed := make([]models.Result, 0, len(attempts))
+	var durationSum float64
+	var durationCount int
+
+	for _, attempt := range attempts {
+		if !attempt.IsCompleted {
+			continue
+		}
+
+		completed = append(completed, attempt)
+		analytics.MeanScore += attempt.Percentage
+		analytics.Distribution[min(max(int(attempt.Percentage)/10, 0), 9)].Count++
+
+		if attempt.SubmittedAt != nil {
+			durationSum += attempt.SubmittedAt.Sub(attempt.CreatedAt).Seconds()
+			durationCount++
+		}
+	}
+
+	analytics.Completed = len(completed)
+
+	if len(completed) == 0 {
+		for _, question := range questions {
+			analytics.Questions = append(analytics.Questions, models.QuestionAnalytics{
+				ID:          question.ID,
+				Title:       question.Title,
+				Type:        question.Type,
+				Distractors: []models.DistractorAnalytics{},
+			})
+		}
+
+		return analytics
+	}
+
+	analytics.MeanScore /= float64(len(completed))
+
+	if durationCount > 0 {
+		analytics.AverageDuration = durationSum / float64(durationCount)
+	}
+
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].Percentage > completed[j].Percentage
+	})
+
+	middle := len(completed) / 2
+
+	if len(completed)%2 == 0 {
+		analytics.MedianScore = (completed[middle-1].Percentage + completed[middle].Percentage) / 2
+	} else {
+		analytics.MedianScore = completed[middle].Percentage
+	}
+
+	groupSize := max(int(math.Round(float64(len(completed))*0.27)), 1)
+	top := make(map[int]bool, groupSize)
+	bottom := make(map[int]bool, groupSize)
+
+	for i := 0; i < groupSize && len(completed) > 1; i++ {
+		top[completed[i].ID] = true
+		bottom[completed[len(completed)-1-i].ID] = true
+	}
+
+	answersByResult := make(map[int]map[int][]models.UserAnswer)
+
+	for _, answer := range userAnswers {
+		if answersByResult[answer.ResultID] == nil {
+			answersByResult[answer.ResultID] = make(map[int][]models.UserAnswer)
+		}
+
+		answersByResult[answer.ResultID][answer.QuestionID] = append(answersByResult[answer.ResultID][answer.QuestionID], answer)
+	}
+
+	versionQuestions := indexVersionQuestions(questionsByVersion)
+	pools := make(map[int]map[int]bool)
+
+	for _, resultQuestion := range resultQuestions {
+		if pools[resultQuestion.ResultID] == nil {
+			pools[resultQuestion.ResultID] = make(map[int]bool)
+		}
+
+		pools[resultQuestion.ResultID][resultQuestion.QuestionID] = true
+	}
+
+	for _, question := range questions {
+		questionAnalytics := models.QuestionAnalytics{
+			ID:          question.ID,
+			Title:       question.Title,
+			Type:        question.Type,
+			Distractors: make([]models.DistractorAnalytics, 0),
+		}
+
+		var scoreSum float64
+		var seen, topSeen, bottomSeen int
+		var topCorrect, bottomCorrect int
+		picked := make(map[int]int)
+
+		for _, attempt := range completed {
+			if pool, ok := pools[attempt.ID]; ok && !pool[question.ID] {
+				continue
+			}
+
+			versionQuestion, ok := versionQuestions[versionKey(attempt.VersionID)][question.ID]
+
+			if !ok {
+				continue
+			}
+
+			answers := answersByResult[attempt.ID][question.ID]
+			score := gradeQuestion(versionQuestion, answers)
+
+			seen++
+			scoreSum += score
+
+			if len(answers) > 0 {
+				questionAnalytics.Answered++
+			}
+
+			for _, answer := range answers {
+				picked[answer.AnswerID]++
+			}
+
+			if top[attempt.ID] {
+				topSeen++
+
+				if score == 1 {
+					topCorrect++
+				}
+			}
+
+			if bottom[attempt.ID] {
+				bottomSeen++
+
+				if score == 1 {
+					bottomCorrect++
+				}
+			}
+		}
+
+		if seen > 0 {
+			questionAnalytics.Difficulty = scoreSum * 100 / float64(seen)
+		}
+
+		if topSeen > 0 && bottomSeen > 0 {
+			questionAnalytics.Discrimination = float64(topCorrect)/float64(topSeen) - float64(bottomCorrect)/float64(bottomSeen)
+		}
+
+		if types.IsChoice(question.Type) {
+			for _, answer := range question.Answers {
+				if answer.IsCorrect {
+					continue
+				}
+
+				distractor := models.DistractorAnalytics{
+					ID:     answer.ID,
+					Text:   answer.Text,
+					Picked: picked[answer.ID],
+				}
+
+				if seen > 0 {
+					distractor.Rate = float64(distractor.Picked) * 100 / float64(seen)
+				}
+
+				questionAnalytics.Distractors = append(questionAnalytics.Distractors, distractor)
+			}
+		}
+
+		analytics.Questions = append(analytics.Questions, questionAnalytics)
 	}
 
-	return s.repo.SubmitResult(ctx, userID, input.AttemptID)
+	return analytics
 }
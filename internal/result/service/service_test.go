@@ -0,0 +1,643 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	mock_question "github.com/blazee5/quizmaster-backend/internal/question/mock"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
+	mock_quiz "github.com/blazee5/quizmaster-backend/internal/quiz/mock"
+	mock_rabbitmq "github.com/blazee5/quizmaster-backend/internal/rabbitmq/mock"
+	mock_result "github.com/blazee5/quizmaster-backend/internal/result/mock"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/blazee5/quizmaster-backend/lib/logger"
+	"github.com/blazee5/quizmaster-backend/lib/tracer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mocks struct {
+	repo         *mock_result.MockRepository
+	quizRepo     *mock_quiz.MockRepository
+	questionRepo *mock_question.MockRepository
+	producer     *mock_rabbitmq.MockQueueProducer
+}
+
+func newTestService(t *testing.T) (*Service, mocks) {
+	ctrl := gomock.NewController(t)
+
+	m := mocks{
+		repo:         mock_result.NewMockRepository(ctrl),
+		quizRepo:     mock_quiz.NewMockRepository(ctrl),
+		questionRepo: mock_question.NewMockRepository(ctrl),
+		producer:     mock_rabbitmq.NewMockQueueProducer(ctrl),
+	}
+
+	service := NewService(logger.NewLogger(), m.repo, m.quizRepo, m.questionRepo, m.producer, tracer.InitTracer("main"))
+
+	return service, m
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestMultipleChoiceScore(t *testing.T) {
+	t.Parallel()
+
+	answers := []models.Answer{
+		{ID: 1, IsCorrect: true},
+		{ID: 2, IsCorrect: true},
+		{ID: 3, IsCorrect: false},
+		{ID: 4, IsCorrect: false},
+	}
+
+	tests := []struct {
+		name     string
+		scoring  string
+		selected []int
+		expected float64
+	}{
+		{"all or nothing exact", "all_or_nothing", []int{1, 2}, 1},
+		{"all or nothing partial", "all_or_nothing", []int{1}, 0},
+		{"all or nothing extra wrong pick", "all_or_nothing", []int{1, 2, 3}, 0},
+		{"proportional partial", "proportional", []int{1}, 0.5},
+		{"proportional select everything", "proportional", []int{1, 2, 3, 4}, 0.5},
+		{"proportional only wrong", "proportional", []int{3}, 0},
+		{"penalty wrong pick cancels correct", "penalty", []int{1, 3}, 0},
+		{"penalty exact", "penalty", []int{1, 2}, 1},
+		{"penalty never negative", "penalty", []int{3, 4}, 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			selected := make(map[int]bool, len(tt.selected))
+
+			for _, id := range tt.selected {
+				selected[id] = true
+			}
+
+			require.InDelta(t, tt.expected, multipleChoiceScore(tt.scoring, answers, selected), 1e-9)
+		})
+	}
+}
+
+func TestGradeQuestion(t *testing.T) {
+	t.Parallel()
+
+	choice := models.QuestionWithAnswers{
+		Type: "choice",
+		Answers: []models.Answer{
+			{ID: 1, IsCorrect: false},
+			{ID: 2, IsCorrect: true},
+		},
+	}
+
+	input := models.QuestionWithAnswers{
+		Type: "input",
+		Answers: []models.Answer{
+			{ID: 3, Text: "Paris"},
+		},
+	}
+
+	require.Equal(t, 1.0, gradeQuestion(choice, []models.UserAnswer{{AnswerID: 2}}))
+	require.Equal(t, 0.0, gradeQuestion(choice, []models.UserAnswer{{AnswerID: 1}}))
+	require.Equal(t, 0.0, gradeQuestion(choice, []models.UserAnswer{}))
+	require.Equal(t, 1.0, gradeQuestion(input, []models.UserAnswer{{Text: "paris"}}))
+	require.Equal(t, 0.0, gradeQuestion(input, []models.UserAnswer{{Text: "London"}}))
+}
+
+func TestGradeQuestionTypes(t *testing.T) {
+	t.Parallel()
+
+	ordering := models.QuestionWithAnswers{
+		Type:    "ordering",
+		Scoring: "proportional",
+		Answers: []models.Answer{
+			{ID: 1, OrderID: 2},
+			{ID: 2, OrderID: 0},
+			{ID: 3, OrderID: 1},
+			{ID: 4, OrderID: 3},
+		},
+	}
+
+	require.Equal(t, 1.0, gradeQuestion(ordering, []models.UserAnswer{{AnswerID: 2}, {AnswerID: 3}, {AnswerID: 1}, {AnswerID: 4}}))
+	require.Equal(t, 0.5, gradeQuestion(ordering, []models.UserAnswer{{AnswerID: 2}, {AnswerID: 1}, {AnswerID: 3}, {AnswerID: 4}}))
+
+	ordering.Scoring = "all_or_nothing"
+	require.Equal(t, 0.0, gradeQuestion(ordering, []models.UserAnswer{{AnswerID: 2}, {AnswerID: 1}, {AnswerID: 3}, {AnswerID: 4}}))
+
+	matching := models.QuestionWithAnswers{
+		Type:    "matching",
+		Scoring: "proportional",
+		Answers: []models.Answer{
+			{ID: 1, Text: "H2O", MatchText: "water"},
+			{ID: 2, Text: "NaCl", MatchText: "salt"},
+		},
+	}
+
+	require.Equal(t, 1.0, gradeQuestion(matching, []models.UserAnswer{{AnswerID: 1, Text: "water"}, {AnswerID: 2, Text: "salt"}}))
+	require.Equal(t, 0.5, gradeQuestion(matching, []models.UserAnswer{{AnswerID: 1, Text: "water"}}))
+	require.Equal(t, 0.0, gradeQuestion(matching, []models.UserAnswer{{AnswerID: 1, Text: "salt"}, {AnswerID: 2, Text: "water"}}))
+
+	numeric := models.QuestionWithAnswers{
+		Type: "numeric",
+		Answers: []models.Answer{
+			{ID: 1, Text: "9.81±0.05"},
+			{ID: 2, Text: "30..40"},
+		},
+	}
+
+	require.Equal(t, 1.0, gradeQuestion(numeric, []models.UserAnswer{{Text: "9,8"}}))
+	require.Equal(t, 1.0, gradeQuestion(numeric, []models.UserAnswer{{Text: "40"}}))
+	require.Equal(t, 0.0, gradeQuestion(numeric, []models.UserAnswer{{Text: "9.7"}}))
+	require.Equal(t, 0.0, gradeQuestion(numeric, []models.UserAnswer{{Text: "ten"}}))
+
+	trueFalse := models.QuestionWithAnswers{
+		Type: "true_false",
+		Answers: []models.Answer{
+			{ID: 1, Text: "True", IsCorrect: true},
+			{ID: 2, Text: "False"},
+		},
+	}
+
+	require.Equal(t, 1.0, gradeQuestion(trueFalse, []models.UserAnswer{{AnswerID: 1}}))
+	require.Equal(t, 0.0, gradeQuestion(trueFalse, []models.UserAnswer{{AnswerID: 2}}))
+}
+
+func TestEssayGrading(t *testing.T) {
+	t.Parallel()
+
+	essay := models.QuestionWithAnswers{ID: 1, Type: "essay", Points: 4}
+	choice := models.QuestionWithAnswers{
+		ID:      2,
+		Type:    "choice",
+		Points:  1,
+		Answers: []models.Answer{{ID: 1, IsCorrect: true}, {ID: 2}},
+	}
+
+	pending := map[int][]models.UserAnswer{
+		1: {{QuestionID: 1, Text: "It evaporates", NeedsReview: true}},
+		2: {{QuestionID: 2, AnswerID: 1}},
+	}
+
+	require.True(t, needsReview(pending))
+
+	score, maxScore := scoreAttempt([]models.QuestionWithAnswers{essay, choice}, pending)
+	require.Equal(t, 1.0, score)
+	require.Equal(t, 5.0, maxScore)
+
+	points := 3.0
+	pending[1][0].Points = &points
+
+	require.False(t, needsReview(pending))
+
+	score, _ = scoreAttempt([]models.QuestionWithAnswers{essay, choice}, pending)
+	require.Equal(t, 4.0, score)
+	require.Equal(t, 0.75, gradeQuestion(essay, pending[1]))
+}
+
+func TestDrawQuestions(t *testing.T) {
+	t.Parallel()
+
+	questions := make([]models.QuestionWithAnswers, 0)
+
+	for i := 1; i <= 10; i++ {
+		tag := "math"
+
+		if i > 6 {
+			tag = "physics"
+		}
+
+		questions = append(questions, models.QuestionWithAnswers{ID: i, Tag: tag})
+	}
+
+	drawn := drawQuestions(questions, 5, true)
+	require.Len(t, drawn, 5)
+
+	tags := make(map[string]int)
+	seen := make(map[int]bool)
+
+	for _, id := range drawn {
+		require.False(t, seen[id])
+		seen[id] = true
+		tags[questions[id-1].Tag]++
+	}
+
+	require.Equal(t, 3, tags["math"])
+	require.Equal(t, 2, tags["physics"])
+
+	require.Len(t, drawQuestions(questions, 4, false), 4)
+	require.Len(t, drawQuestions(questions, 20, false), 10)
+}
+
+func TestBuildAnalytics(t *testing.T) {
+	t.Parallel()
+
+	questions := []models.QuestionWithAnswers{
+		{
+			ID:   1,
+			Type: "choice",
+			Answers: []models.Answer{
+				{ID: 10, IsCorrect: true},
+				{ID: 11, IsCorrect: false},
+			},
+		},
+	}
+
+	attempts := []models.Result{
+		{ID: 1, IsCompleted: true, Percentage: 100},
+		{ID: 2, IsCompleted: true, Percentage: 0},
+		{ID: 3, IsCompleted: true, Percentage: 100},
+		{ID: 4, IsCompleted: true, Percentage: 0},
+		{ID: 5, IsCompleted: false},
+	}
+
+	userAnswers := []models.UserAnswer{
+		{ResultID: 1, QuestionID: 1, AnswerID: 10},
+		{ResultID: 2, QuestionID: 1, AnswerID: 11},
+		{ResultID: 3, QuestionID: 1, AnswerID: 10},
+		{ResultID: 4, QuestionID: 1, AnswerID: 11},
+	}
+
+	analytics := buildAnalytics(attempts, questions, map[int][]models.QuestionWithAnswers{0: questions}, userAnswers, nil)
+
+	require.Equal(t, 5, analytics.Started)
+	require.Equal(t, 4, analytics.Completed)
+	require.Equal(t, 50.0, analytics.MeanScore)
+	require.Equal(t, 50.0, analytics.MedianScore)
+	require.Equal(t, 2, analytics.Distribution[0].Count)
+	require.Equal(t, 2, analytics.Distribution[9].Count)
+	require.Len(t, analytics.Questions, 1)
+	require.Equal(t, 50.0, analytics.Questions[0].Difficulty)
+	require.Equal(t, 1.0, analytics.Questions[0].Discrimination)
+	require.Equal(t, []models.DistractorAnalytics{{ID: 11, Picked: 2, Rate: 50}}, analytics.Questions[0].Distractors)
+}
+
+func TestService_AnalyticsAndExportUseAttemptVersion(t *testing.T) {
+	t.Parallel()
+
+	published := []models.QuestionWithAnswers{
+		{
+			ID:     5,
+			Title:  "Capital of France?",
+			Type:   "choice",
+			Points: 1,
+			Answers: []models.Answer{
+				{ID: 50, Text: "Paris", IsCorrect: true},
+				{ID: 51, Text: "Lyon"},
+			},
+		},
+	}
+	edited := []models.QuestionWithAnswers{
+		{
+			ID:     5,
+			Title:  "Capital of Italy?",
+			Type:   "choice",
+			Points: 1,
+			Answers: []models.Answer{
+				{ID: 50, Text: "Rome"},
+				{ID: 51, Text: "Milan", IsCorrect: true},
+			},
+		},
+		{ID: 6, Title: "Capital of Spain?", Type: "input", Points: 1, Answers: []models.Answer{{ID: 60, Text: "Madrid", IsCorrect: true}}},
+	}
+
+	tests := []struct {
+		name    string
+		current *int
+	}{
+		{"draft edited after the attempt", intPtr(1)},
+		{"edited draft published", intPtr(2)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, m := newTestService(t)
+
+			quiz := models.Quiz{ID: 1, UserID: 1, VersionID: tt.current}
+			attempts := []models.Result{{ID: 7, QuizID: 1, UserID: 2, IsCompleted: true, Percentage: 100, VersionID: intPtr(1)}}
+			answers := []models.UserAnswer{{ResultID: 7, QuestionID: 5, AnswerID: 50}}
+
+			m.quizRepo.EXPECT().GetByID(gomock.Any(), 1).Return(quiz, nil).Times(2)
+			m.repo.EXPECT().GetAttemptsByQuizID(gomock.Any(), 1).Return(attempts, nil).Times(2)
+			m.quizRepo.EXPECT().GetVersion(gomock.Any(), 1).Return(models.QuizVersion{ID: 1, Questions: published}, nil).AnyTimes()
+			m.quizRepo.EXPECT().GetVersion(gomock.Any(), 2).Return(models.QuizVersion{ID: 2, Questions: edited}, nil).AnyTimes()
+			m.repo.EXPECT().GetUserAnswersByQuizID(gomock.Any(), 1).Return(answers, nil)
+			m.repo.EXPECT().GetResultQuestionsByQuizID(gomock.Any(), 1).Return([]models.ResultQuestion{}, nil)
+			m.repo.EXPECT().ExportResults(gomock.Any(), 1, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ int, fn func(models.ResultExport) error) error {
+					return fn(models.ResultExport{
+						ResultID:  7,
+						Username:  "taker",
+						Score:     1,
+						MaxScore:  1,
+						Status:    "completed",
+						VersionID: intPtr(1),
+						Answers:   map[int][]models.UserAnswer{5: {{QuestionID: 5, AnswerID: 50}}},
+					})
+				})
+
+			analytics, err := service.GetAnalytics(context.Background(), 1, 1)
+
+			require.NoError(t, err)
+			require.Equal(t, 100.0, analytics.Questions[0].Difficulty)
+			require.Equal(t, 5, analytics.Questions[0].ID)
+
+			for _, question := range analytics.Questions[1:] {
+				require.Zero(t, question.Answered)
+			}
+
+			var buf bytes.Buffer
+
+			require.NoError(t, service.ExportResults(context.Background(), 1, 1, "csv", &buf))
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+			require.Len(t, lines, 2)
+			require.Contains(t, lines[1], "Paris")
+			require.NotContains(t, lines[1], "Rome")
+		})
+	}
+}
+
+func TestService_SubmitExpiredResultsSkipsSubmitted(t *testing.T) {
+	t.Parallel()
+
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	expired := []models.Result{
+		{ID: 1, UserID: 10, QuizID: 1},
+		{ID: 2, UserID: 11, QuizID: 1},
+	}
+
+	m.repo.EXPECT().LockExpired(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func() error) error {
+		return fn()
+	})
+	m.repo.EXPECT().GetExpired(gomock.Any()).Return(expired, nil)
+	m.questionRepo.EXPECT().GetQuestionsAuthor(gomock.Any(), 1).Return(nil, nil).Times(2)
+	m.repo.EXPECT().GetResultQuestions(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	m.repo.EXPECT().GetUserAnswers(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	m.repo.EXPECT().SubmitResult(gomock.Any(), 10, 1, 0.0, 0.0, false).Return(models.UsersResult{}, http_errors.ErrAlreadySubmitted)
+	m.repo.EXPECT().SubmitResult(gomock.Any(), 11, 2, 0.0, 0.0, false).Return(models.UsersResult{ID: 2}, nil)
+
+	submitted, err := s.SubmitExpiredResults(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, []models.Result{expired[1]}, submitted)
+}
+
+func TestService_SaveUserAnswer(t *testing.T) {
+	t.Parallel()
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Minute)
+
+	question := models.QuestionWithAnswers{
+		ID:      5,
+		Type:    types.Choice,
+		Points:  1,
+		Answers: []models.Answer{{ID: 50, IsCorrect: true}, {ID: 51}},
+	}
+
+	tests := []struct {
+		name     string
+		attempt  models.Result
+		answerID int
+		expected error
+	}{
+		{"saves answer", models.Result{ID: 3, UserID: 10, QuizID: 1, ExpiresAt: &future}, 51, nil},
+		{"no deadline", models.Result{ID: 3, UserID: 10, QuizID: 1}, 50, nil},
+		{"deadline passed", models.Result{ID: 3, UserID: 10, QuizID: 1, ExpiresAt: &past}, 50, http_errors.ErrAttemptExpired},
+		{"submitted attempt", models.Result{ID: 3, UserID: 10, QuizID: 1, IsCompleted: true}, 50, http_errors.ErrPermissionDenied},
+		{"other user attempt", models.Result{ID: 3, UserID: 11, QuizID: 1}, 50, http_errors.ErrPermissionDenied},
+		{"unknown answer", models.Result{ID: 3, UserID: 10, QuizID: 1}, 99, http_errors.ErrWrongArgument},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, m := newTestService(t)
+			ctx := context.Background()
+
+			m.quizRepo.EXPECT().GetByID(gomock.Any(), 1).Return(models.Quiz{ID: 1}, nil)
+			m.repo.EXPECT().GetByID(gomock.Any(), 3).Return(tt.attempt, nil)
+
+			if tt.expected == nil || tt.expected == http_errors.ErrWrongArgument {
+				m.questionRepo.EXPECT().GetQuestionsAuthor(gomock.Any(), 1).Return([]models.QuestionWithAnswers{question}, nil)
+				m.repo.EXPECT().GetResultQuestions(gomock.Any(), 3).Return(nil, nil)
+			}
+
+			if tt.expected == nil {
+				m.repo.EXPECT().SaveUserAnswers(gomock.Any(), 3, 5, []models.UserAnswer{{UserID: 10, QuestionID: 5, ResultID: 3, AnswerID: tt.answerID}}).Return(nil)
+			}
+
+			attempt, err := s.SaveUserAnswer(ctx, 10, 1, domain.UserAnswer{AttemptID: 3, QuestionID: 5, AnswerID: tt.answerID})
+
+			if tt.expected != nil {
+				require.ErrorIs(t, err, tt.expected)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 3, attempt.ID)
+		})
+	}
+}
+
+func TestService_ChangedAnswerIsRescored(t *testing.T) {
+	t.Parallel()
+
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	question := models.QuestionWithAnswers{
+		ID:      5,
+		Type:    types.Choice,
+		Points:  2,
+		Answers: []models.Answer{{ID: 50, IsCorrect: true}, {ID: 51}},
+	}
+	attempt := models.Result{ID: 3, UserID: 10, QuizID: 1}
+
+	saved := make(map[int][]models.UserAnswer)
+
+	m.quizRepo.EXPECT().GetByID(gomock.Any(), 1).Return(models.Quiz{ID: 1}, nil).AnyTimes()
+	m.repo.EXPECT().GetByID(gomock.Any(), 3).Return(attempt, nil).AnyTimes()
+	m.questionRepo.EXPECT().GetQuestionsAuthor(gomock.Any(), 1).Return([]models.QuestionWithAnswers{question}, nil).AnyTimes()
+	m.repo.EXPECT().GetResultQuestions(gomock.Any(), 3).Return(nil, nil).AnyTimes()
+	m.repo.EXPECT().SaveUserAnswers(gomock.Any(), 3, 5, gomock.Any()).DoAndReturn(func(_ context.Context, _, questionID int, answers []models.UserAnswer) error {
+		saved[questionID] = answers
+
+		return nil
+	}).Times(2)
+	m.repo.EXPECT().GetUserAnswers(gomock.Any(), 3).DoAndReturn(func(_ context.Context, _ int) ([]models.UserAnswer, error) {
+		return saved[5], nil
+	})
+	m.repo.EXPECT().SubmitResult(gomock.Any(), 10, 3, 2.0, 2.0, false).Return(models.UsersResult{ID: 3, Score: 2, MaxScore: 2}, nil)
+
+	_, err := s.SaveUserAnswer(ctx, 10, 1, domain.UserAnswer{AttemptID: 3, QuestionID: 5, AnswerID: 51})
+	require.NoError(t, err)
+
+	_, err = s.SaveUserAnswer(ctx, 10, 1, domain.UserAnswer{AttemptID: 3, QuestionID: 5, AnswerID: 50})
+	require.NoError(t, err)
+
+	require.Len(t, saved[5], 1)
+	require.Equal(t, 50, saved[5][0].AnswerID)
+
+	result, err := s.SubmitResult(ctx, 10, 1, domain.SubmitResult{AttemptID: 3})
+
+	require.NoError(t, err)
+	require.Equal(t, 2.0, result.Score)
+}
+
+func TestService_NewResultAttemptPolicy(t *testing.T) {
+	t.Parallel()
+
+	recent := time.Now().Add(-10 * time.Minute)
+	old := time.Now().Add(-2 * time.Hour)
+
+	tests := []struct {
+		name        string
+		maxAttempts int
+		cooldown    int
+		count       int
+		last        *models.Result
+		raceLost    bool
+		expected    error
+	}{
+		{"no limits", 0, 0, 0, nil, false, nil},
+		{"under max attempts", 2, 0, 1, nil, false, nil},
+		{"max attempts reached", 2, 0, 2, nil, false, http_errors.ErrAttemptsExceeded},
+		{"max attempts reached concurrently", 2, 0, 1, nil, true, http_errors.ErrAttemptsExceeded},
+		{"first attempt with cooldown", 0, 3600, 0, nil, false, nil},
+		{"cooldown active", 0, 3600, 0, &models.Result{CreatedAt: recent, SubmittedAt: &recent}, false, http_errors.ErrAttemptCooldown},
+		{"cooldown over", 0, 3600, 0, &models.Result{CreatedAt: old, SubmittedAt: &old}, false, nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, m := newTestService(t)
+			ctx := context.Background()
+
+			quiz := models.Quiz{
+				ID:              1,
+				Status:          "published",
+				Visibility:      "public",
+				VersionID:       intPtr(4),
+				MaxAttempts:     tt.maxAttempts,
+				AttemptCooldown: tt.cooldown,
+			}
+
+			m.quizRepo.EXPECT().GetByID(gomock.Any(), 1).Return(quiz, nil)
+			m.repo.EXPECT().GetByUserID(gomock.Any(), 10, 1).Return(models.Result{}, sql.ErrNoRows)
+
+			if tt.maxAttempts > 0 {
+				m.repo.EXPECT().CountAttempts(gomock.Any(), 10, 1).Return(tt.count, nil)
+			}
+
+			if tt.cooldown > 0 {
+				if tt.last != nil {
+					m.repo.EXPECT().GetLastAttempt(gomock.Any(), 10, 1).Return(*tt.last, nil)
+				} else {
+					m.repo.EXPECT().GetLastAttempt(gomock.Any(), 10, 1).Return(models.Result{}, sql.ErrNoRows)
+				}
+			}
+
+			if tt.expected == nil {
+				m.repo.EXPECT().NewResult(gomock.Any(), 10, 1, 4, 0, tt.maxAttempts, nil).Return(models.Result{ID: 3}, nil)
+			}
+
+			if tt.raceLost {
+				m.repo.EXPECT().NewResult(gomock.Any(), 10, 1, 4, 0, tt.maxAttempts, nil).Return(models.Result{}, sql.ErrNoRows)
+			}
+
+			attempt, err := s.NewResult(ctx, 10, 1, "")
+
+			if tt.expected != nil {
+				require.ErrorIs(t, err, tt.expected)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 3, attempt.ID)
+		})
+	}
+}
+
+func TestService_NewResultResumesOpenAttempt(t *testing.T) {
+	t.Parallel()
+
+	future := time.Now().Add(10 * time.Minute)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		current  models.Result
+		resumed  bool
+		timeLeft bool
+	}{
+		{"open attempt without time limit", models.Result{ID: 3, UserID: 10, QuizID: 1}, true, false},
+		{"open attempt with time left", models.Result{ID: 3, UserID: 10, QuizID: 1, ExpiresAt: &future}, true, true},
+		{"expired attempt is submitted", models.Result{ID: 3, UserID: 10, QuizID: 1, ExpiresAt: &past}, false, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, m := newTestService(t)
+			ctx := context.Background()
+
+			quiz := models.Quiz{ID: 1, Status: "published", Visibility: "public", VersionID: intPtr(4)}
+
+			m.quizRepo.EXPECT().GetByID(gomock.Any(), 1).Return(quiz, nil)
+			m.repo.EXPECT().GetByUserID(gomock.Any(), 10, 1).Return(tt.current, nil)
+
+			if !tt.resumed {
+				m.questionRepo.EXPECT().GetQuestionsAuthor(gomock.Any(), 1).Return(nil, nil)
+				m.repo.EXPECT().GetResultQuestions(gomock.Any(), 3).Return(nil, nil)
+				m.repo.EXPECT().GetUserAnswers(gomock.Any(), 3).Return(nil, nil)
+				m.repo.EXPECT().SubmitResult(gomock.Any(), 10, 3, 0.0, 0.0, false).Return(models.UsersResult{ID: 3}, nil)
+				m.repo.EXPECT().NewResult(gomock.Any(), 10, 1, 4, 0, 0, nil).Return(models.Result{ID: 8}, nil)
+			}
+
+			attempt, err := s.NewResult(ctx, 10, 1, "")
+
+			require.NoError(t, err)
+
+			if !tt.resumed {
+				require.Equal(t, 8, attempt.ID)
+
+				return
+			}
+
+			require.Equal(t, 3, attempt.ID)
+			require.Equal(t, tt.timeLeft, attempt.TimeLeft != nil)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	questionHandler "github.com/blazee5/quizmaster-backend/internal/question/handler"
 	quizHandler "github.com/blazee5/quizmaster-backend/internal/quiz/handler"
 	resultHandler "github.com/blazee5/quizmaster-backend/internal/result/handler"
+	sessionHandler "github.com/blazee5/quizmaster-backend/internal/session/handler"
 	userHandler "github.com/blazee5/quizmaster-backend/internal/user/handler"
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
@@ -24,20 +25,23 @@ func (s *Server) InitRoutes(e *echo.Echo) {
 	userGroup := apiGroup.Group("/user", middleware.AuthMiddleware)
 	questionGroup := quizGroup.Group("/:id/questions", middleware.AuthMiddleware)
 	answerGroup := questionGroup.Group("/:questionID/answers")
+	sessionGroup := e.Group("/session", middleware.AuthMiddleware)
 	adminGroup := e.Group("/admin")
 	adminAuthGroup := adminGroup.Group("/auth")
 	adminUsersGroup := adminGroup.Group("/users", middleware.AdminMiddleware)
 	adminQuizzesGroup := adminGroup.Group("/quizzes", middleware.AdminMiddleware)
 
-	authHandler.InitAuthRoutes(authGroup, s.log, s.db, s.rabbitConn, s.tracer)
+	authHandler.InitAuthRoutes(authGroup, s.log, s.db, s.producer, s.tracer)
 	userHandler.InitUserRoutes(userGroup, s.log, s.db, s.rdb, s.awsClient, s.tracer)
 	quizHandler.InitQuizRoutes(quizGroup, s.log, s.db, s.rdb, s.esClient, s.awsClient, s.tracer)
-	resultHandler.InitResultRoutes(quizGroup, s.log, s.db, s.ws, s.tracer)
+	resultHandler.InitResultRoutes(quizGroup, s.log, s.db, s.ws, s.producer, s.tracer)
 	questionHandler.InitQuestionRoutes(questionGroup, s.log, s.db, s.awsClient, s.tracer)
-	answerHandler.InitAnswerRoutes(answerGroup, s.log, s.db, s.tracer)
+	answerHandler.InitAnswerRoutes(answerGroup, s.log, s.db, s.awsClient, s.tracer)
+	sessionHandler.InitSessionRoutes(sessionGroup, s.log, s.db, s.rdb, s.ws, s.producer, s.tracer)
 	adminAuthHandler.InitAdminAuthRoutes(adminAuthGroup, s.log, s.db, s.tracer)
 	adminUserHandler.InitAdminUserRoutes(adminUsersGroup, s.log, s.db, s.tracer)
 	adminQuizHandler.InitAdminQuizRoutes(adminQuizzesGroup, s.log, s.db, s.tracer)
+	resultHandler.InitAdminResultRoutes(adminQuizzesGroup, s.log, s.db, s.ws, s.producer, s.tracer)
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
@@ -2,11 +2,11 @@ package routes
 
 import (
 	"context"
+	"github.com/blazee5/quizmaster-backend/internal/rabbitmq"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
 	"github.com/minio/minio-go/v7"
-	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/redis/go-redis/v9"
 	socketio "github.com/vchitai/go-socket.io/v4"
 	"go.opentelemetry.io/otel/trace"
@@ -26,19 +26,19 @@ const (
 )
 
 type Server struct {
-	echo       *echo.Echo
-	log        *zap.SugaredLogger
-	db         *sqlx.DB
-	rdb        *redis.Client
-	esClient   *elasticsearch.Client
-	ws         *socketio.Server
-	tracer     trace.Tracer
-	awsClient  *minio.Client
-	rabbitConn *amqp.Connection
+	echo      *echo.Echo
+	log       *zap.SugaredLogger
+	db        *sqlx.DB
+	rdb       *redis.Client
+	esClient  *elasticsearch.Client
+	ws        *socketio.Server
+	tracer    trace.Tracer
+	awsClient *minio.Client
+	producer  rabbitmq.QueueProducer
 }
 
-func NewServer(echo *echo.Echo, log *zap.SugaredLogger, db *sqlx.DB, rdb *redis.Client, esClient *elasticsearch.Client, ws *socketio.Server, tracer trace.Tracer, awsClient *minio.Client, rabbitConn *amqp.Connection) *Server {
-	return &Server{echo: echo, log: log, db: db, rdb: rdb, esClient: esClient, ws: ws, tracer: tracer, awsClient: awsClient, rabbitConn: rabbitConn}
+func NewServer(echo *echo.Echo, log *zap.SugaredLogger, db *sqlx.DB, rdb *redis.Client, esClient *elasticsearch.Client, ws *socketio.Server, tracer trace.Tracer, awsClient *minio.Client, producer rabbitmq.QueueProducer) *Server {
+	return &Server{echo: echo, log: log, db: db, rdb: rdb, esClient: esClient, ws: ws, tracer: tracer, awsClient: awsClient, producer: producer}
 }
 
 func (s *Server) Run() error {
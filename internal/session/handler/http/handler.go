@@ -0,0 +1,282 @@
+package http
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/blazee5/quizmaster-backend/internal/session"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/blazee5/quizmaster-backend/lib/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	socketio "github.com/vchitai/go-socket.io/v4"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type Handler struct {
+	log     *zap.SugaredLogger
+	service session.Service
+	ws      *socketio.Server
+	tracer  trace.Tracer
+}
+
+func NewHandler(log *zap.SugaredLogger, service session.Service, ws *socketio.Server, tracer trace.Tracer) *Handler {
+	return &Handler{log: log, service: service, ws: ws, tracer: tracer}
+}
+
+func (h *Handler) CreateSession(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "session.CreateSession")
+	defer span.End()
+
+	var input domain.Session
+
+	userID := c.Get("userID").(int)
+
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "bad request",
+		})
+	}
+
+	if err := c.Validate(&input); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": response.ValidationError(validateErr),
+		})
+	}
+
+	liveSession, err := h.service.Create(ctx, userID, input)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "quiz not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "wrong argument",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while create session: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, liveSession)
+}
+
+func (h *Handler) GetSession(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "session.GetSession")
+	defer span.End()
+
+	liveSession, err := h.service.GetByPIN(ctx, c.Param("pin"))
+
+	if errors.Is(err, redis.Nil) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "session not found",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while get session: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, liveSession)
+}
+
+func (h *Handler) NextQuestion(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "session.NextQuestion")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	pin := c.Param("pin")
+
+	question, err := h.service.NextQuestion(ctx, userID, pin)
+
+	if errors.Is(err, redis.Nil) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "session not found",
+		})
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "question not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "wrong argument",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while start session question: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	h.ws.BroadcastToRoom("/live", "session:"+pin, "question", question)
+
+	time.AfterFunc(time.Until(question.EndsAt), func() {
+		h.closeQuestion(pin, question.Index)
+	})
+
+	return c.JSON(http.StatusOK, question)
+}
+
+func (h *Handler) EndQuestion(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "session.EndQuestion")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	pin := c.Param("pin")
+
+	stats, err := h.service.EndQuestion(ctx, userID, pin)
+
+	if errors.Is(err, redis.Nil) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "session not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "wrong argument",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while end session question: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	h.broadcastStats(ctx, pin, stats)
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+func (h *Handler) FinishSession(c echo.Context) error {
+	ctx, span := h.tracer.Start(c.Request().Context(), "session.FinishSession")
+	defer span.End()
+
+	userID := c.Get("userID").(int)
+	pin := c.Param("pin")
+
+	leaderboard, err := h.service.Finish(ctx, userID, pin)
+
+	if errors.Is(err, redis.Nil) {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"message": "session not found",
+		})
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return c.JSON(http.StatusForbidden, echo.Map{
+			"message": "permission denied",
+		})
+	}
+
+	if err != nil {
+		h.log.Infof("error while finish session: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "server error",
+		})
+	}
+
+	h.ws.BroadcastToRoom("/live", "session:"+pin, "finished", leaderboard)
+
+	return c.JSON(http.StatusOK, leaderboard)
+}
+
+func (h *Handler) closeQuestion(pin string, index int) {
+	ctx, span := h.tracer.Start(context.Background(), "session.closeQuestion")
+	defer span.End()
+
+	stats, err := h.service.CloseQuestion(ctx, pin, index)
+
+	if errors.Is(err, http_errors.ErrWrongArgument) || errors.Is(err, redis.Nil) {
+		return
+	}
+
+	if err != nil {
+		h.log.Infof("error while close session question: %s", err)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return
+	}
+
+	h.broadcastStats(ctx, pin, stats)
+}
+
+func (h *Handler) broadcastStats(ctx context.Context, pin string, stats models.SessionQuestionStats) {
+	h.ws.BroadcastToRoom("/live", "session:"+pin, "question_stats", stats)
+
+	leaderboard, err := h.service.GetLeaderboard(ctx, pin)
+
+	if err != nil {
+		h.log.Infof("error while get session leaderboard: %s", err)
+		return
+	}
+
+	h.ws.BroadcastToRoom("/live", "session:"+pin, "leaderboard", leaderboard)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	answerRepo "github.com/blazee5/quizmaster-backend/internal/answer/repository"
+	questionRepo "github.com/blazee5/quizmaster-backend/internal/question/repository"
+	quizRepo "github.com/blazee5/quizmaster-backend/internal/quiz/repository"
+	"github.com/blazee5/quizmaster-backend/internal/rabbitmq"
+	resultRepo "github.com/blazee5/quizmaster-backend/internal/result/repository"
+	resultService "github.com/blazee5/quizmaster-backend/internal/result/service"
+	"github.com/blazee5/quizmaster-backend/internal/session/handler/http"
+	wsHandler "github.com/blazee5/quizmaster-backend/internal/session/handler/ws"
+	sessionRepo "github.com/blazee5/quizmaster-backend/internal/session/repository"
+	sessionService "github.com/blazee5/quizmaster-backend/internal/session/service"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	socketio "github.com/vchitai/go-socket.io/v4"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func InitSessionRoutes(sessionGroup *echo.Group, log *zap.SugaredLogger, db *sqlx.DB, rdb *redis.Client, ws *socketio.Server, producer rabbitmq.QueueProducer, tracer trace.Tracer) {
+	redisRepos := sessionRepo.NewSessionRedisRepo(rdb, tracer)
+	quizRepos := quizRepo.NewRepository(db, tracer)
+	questionRepos := questionRepo.NewRepository(db, tracer)
+	answerRepos := answerRepo.NewRepository(db, tracer)
+	resultServices := resultService.NewService(log, resultRepo.NewRepository(db, tracer), quizRepos, questionRepos, producer, tracer)
+	services := sessionService.NewService(log, redisRepos, quizRepos, questionRepos, answerRepos, resultServices, tracer)
+	handlers := http.NewHandler(log, services, ws, tracer)
+	wsHandlers := wsHandler.NewHandler(log, services, ws, tracer)
+
+	sessionGroup.POST("", handlers.CreateSession)
+	sessionGroup.GET("/:pin", handlers.GetSession)
+	sessionGroup.POST("/:pin/next", handlers.NextQuestion)
+	sessionGroup.POST("/:pin/end", handlers.EndQuestion)
+	sessionGroup.POST("/:pin/finish", handlers.FinishSession)
+
+	ws.OnEvent("/live", "watch", wsHandlers.Watch)
+	ws.OnEvent("/live", "join", wsHandlers.Join)
+	ws.OnEvent("/live", "answer", wsHandlers.Answer)
+}
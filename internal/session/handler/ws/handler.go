@@ -0,0 +1,103 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/session"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/redis/go-redis/v9"
+	socketio "github.com/vchitai/go-socket.io/v4"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type Handler struct {
+	log     *zap.SugaredLogger
+	service session.Service
+	ws      *socketio.Server
+	tracer  trace.Tracer
+}
+
+func NewHandler(log *zap.SugaredLogger, service session.Service, ws *socketio.Server, tracer trace.Tracer) *Handler {
+	return &Handler{log: log, service: service, ws: ws, tracer: tracer}
+}
+
+func (h *Handler) Watch(conn socketio.Conn, pin string) interface{} {
+	ctx, span := h.tracer.Start(context.Background(), "sessionWs.Watch")
+	defer span.End()
+
+	liveSession, err := h.service.GetByPIN(ctx, pin)
+
+	if errors.Is(err, redis.Nil) {
+		return "session not found"
+	}
+
+	if err != nil {
+		h.log.Infof("error while get session: %s", err)
+		return "server error"
+	}
+
+	conn.Join("session:" + pin)
+
+	return liveSession
+}
+
+func (h *Handler) Join(conn socketio.Conn, input domain.SessionJoin) interface{} {
+	ctx, span := h.tracer.Start(context.Background(), "sessionWs.Join")
+	defer span.End()
+
+	player, players, err := h.service.Join(ctx, input)
+
+	if errors.Is(err, redis.Nil) {
+		return "session not found"
+	}
+
+	if errors.Is(err, http_errors.ErrNicknameTaken) {
+		return "nickname is taken"
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return "session is finished"
+	}
+
+	if errors.Is(err, http_errors.ErrWrongArgument) {
+		return "wrong argument"
+	}
+
+	if err != nil {
+		h.log.Infof("error while join session: %s", err)
+		return "server error"
+	}
+
+	conn.Join("session:" + input.PIN)
+	h.ws.BroadcastToRoom("/live", "session:"+input.PIN, "players", players)
+
+	return player
+}
+
+func (h *Handler) Answer(conn socketio.Conn, input domain.SessionAnswer) interface{} {
+	ctx, span := h.tracer.Start(context.Background(), "sessionWs.Answer")
+	defer span.End()
+
+	err := h.service.Answer(ctx, input)
+
+	if errors.Is(err, redis.Nil) {
+		return "session not found"
+	}
+
+	if errors.Is(err, http_errors.ErrAttemptExpired) {
+		return "time is over"
+	}
+
+	if errors.Is(err, http_errors.ErrPermissionDenied) {
+		return "already answered"
+	}
+
+	if err != nil {
+		h.log.Infof("error while answer session question: %s", err)
+		return "server error"
+	}
+
+	return "ok"
+}
@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/session/redis_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/session/redis_repository.go -destination internal/session/mock/redis_repository_mock.go
+//
+// Package mock_session is a generated GoMock package.
+package mock_session
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/blazee5/quizmaster-backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisRepository is a mock of RedisRepository interface.
+type MockRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisRepositoryMockRecorder
+}
+
+// MockRedisRepositoryMockRecorder is the mock recorder for MockRedisRepository.
+type MockRedisRepositoryMockRecorder struct {
+	mock *MockRedisRepository
+}
+
+// NewMockRedisRepository creates a new mock instance.
+func NewMockRedisRepository(ctrl *gomock.Controller) *MockRedisRepository {
+	mock := &MockRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisRepository) EXPECT() *MockRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddPlayer mocks base method.
+func (m *MockRedisRepository) AddPlayer(ctx context.Context, pin string, player models.SessionPlayer) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPlayer", ctx, pin, player)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPlayer indicates an expected call of AddPlayer.
+func (mr *MockRedisRepositoryMockRecorder) AddPlayer(ctx, pin, player any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlayer", reflect.TypeOf((*MockRedisRepository)(nil).AddPlayer), ctx, pin, player)
+}
+
+// AddScore mocks base method.
+func (m *MockRedisRepository) AddScore(ctx context.Context, pin, nickname string, score float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddScore", ctx, pin, nickname, score)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddScore indicates an expected call of AddScore.
+func (mr *MockRedisRepositoryMockRecorder) AddScore(ctx, pin, nickname, score any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddScore", reflect.TypeOf((*MockRedisRepository)(nil).AddScore), ctx, pin, nickname, score)
+}
+
+// CloseQuestion mocks base method.
+func (m *MockRedisRepository) CloseQuestion(ctx context.Context, pin string, index int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseQuestion", ctx, pin, index)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseQuestion indicates an expected call of CloseQuestion.
+func (mr *MockRedisRepositoryMockRecorder) CloseQuestion(ctx, pin, index any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseQuestion", reflect.TypeOf((*MockRedisRepository)(nil).CloseQuestion), ctx, pin, index)
+}
+
+// CreateSession mocks base method.
+func (m *MockRedisRepository) CreateSession(ctx context.Context, session models.Session) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockRedisRepositoryMockRecorder) CreateSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockRedisRepository)(nil).CreateSession), ctx, session)
+}
+
+// GetAnswers mocks base method.
+func (m *MockRedisRepository) GetAnswers(ctx context.Context, pin string, index int) (map[string]models.SessionAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnswers", ctx, pin, index)
+	ret0, _ := ret[0].(map[string]models.SessionAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnswers indicates an expected call of GetAnswers.
+func (mr *MockRedisRepositoryMockRecorder) GetAnswers(ctx, pin, index any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswers", reflect.TypeOf((*MockRedisRepository)(nil).GetAnswers), ctx, pin, index)
+}
+
+// GetLeaderboard mocks base method.
+func (m *MockRedisRepository) GetLeaderboard(ctx context.Context, pin string) ([]models.SessionScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", ctx, pin)
+	ret0, _ := ret[0].([]models.SessionScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *MockRedisRepositoryMockRecorder) GetLeaderboard(ctx, pin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*MockRedisRepository)(nil).GetLeaderboard), ctx, pin)
+}
+
+// GetPlayer mocks base method.
+func (m *MockRedisRepository) GetPlayer(ctx context.Context, pin, token string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayer", ctx, pin, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayer indicates an expected call of GetPlayer.
+func (mr *MockRedisRepositoryMockRecorder) GetPlayer(ctx, pin, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayer", reflect.TypeOf((*MockRedisRepository)(nil).GetPlayer), ctx, pin, token)
+}
+
+// GetPlayers mocks base method.
+func (m *MockRedisRepository) GetPlayers(ctx context.Context, pin string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayers", ctx, pin)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayers indicates an expected call of GetPlayers.
+func (mr *MockRedisRepositoryMockRecorder) GetPlayers(ctx, pin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayers", reflect.TypeOf((*MockRedisRepository)(nil).GetPlayers), ctx, pin)
+}
+
+// GetSession mocks base method.
+func (m *MockRedisRepository) GetSession(ctx context.Context, pin string) (models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, pin)
+	ret0, _ := ret[0].(models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockRedisRepositoryMockRecorder) GetSession(ctx, pin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockRedisRepository)(nil).GetSession), ctx, pin)
+}
+
+// SaveAnswer mocks base method.
+func (m *MockRedisRepository) SaveAnswer(ctx context.Context, pin string, index int, nickname string, answer models.SessionAnswer) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAnswer", ctx, pin, index, nickname, answer)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveAnswer indicates an expected call of SaveAnswer.
+func (mr *MockRedisRepositoryMockRecorder) SaveAnswer(ctx, pin, index, nickname, answer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAnswer", reflect.TypeOf((*MockRedisRepository)(nil).SaveAnswer), ctx, pin, index, nickname, answer)
+}
+
+// UpdateSession mocks base method.
+func (m *MockRedisRepository) UpdateSession(ctx context.Context, session models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSession indicates an expected call of UpdateSession.
+func (mr *MockRedisRepositoryMockRecorder) UpdateSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSession", reflect.TypeOf((*MockRedisRepository)(nil).UpdateSession), ctx, session)
+}
@@ -0,0 +1,20 @@
+package session
+
+import (
+	"context"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+)
+
+type RedisRepository interface {
+	CreateSession(ctx context.Context, session models.Session) (bool, error)
+	GetSession(ctx context.Context, pin string) (models.Session, error)
+	UpdateSession(ctx context.Context, session models.Session) error
+	AddPlayer(ctx context.Context, pin string, player models.SessionPlayer) (bool, error)
+	GetPlayer(ctx context.Context, pin, token string) (string, error)
+	GetPlayers(ctx context.Context, pin string) ([]string, error)
+	SaveAnswer(ctx context.Context, pin string, index int, nickname string, answer models.SessionAnswer) (bool, error)
+	GetAnswers(ctx context.Context, pin string, index int) (map[string]models.SessionAnswer, error)
+	CloseQuestion(ctx context.Context, pin string, index int) (bool, error)
+	AddScore(ctx context.Context, pin, nickname string, score float64) error
+	GetLeaderboard(ctx context.Context, pin string) ([]models.SessionScore, error)
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
+	"strconv"
+	"time"
+)
+
+const sessionTTL = 6 * time.Hour
+
+type SessionRedisRepo struct {
+	redisClient *redis.Client
+	tracer      trace.Tracer
+}
+
+func NewSessionRedisRepo(redisClient *redis.Client, tracer trace.Tracer) *SessionRedisRepo {
+	return &SessionRedisRepo{redisClient: redisClient, tracer: tracer}
+}
+
+func (repo *SessionRedisRepo) CreateSession(ctx context.Context, session models.Session) (bool, error) {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.CreateSession")
+	defer span.End()
+
+	sessionBytes, err := json.Marshal(session)
+
+	if err != nil {
+		return false, err
+	}
+
+	return repo.redisClient.SetNX(ctx, "session:"+session.PIN, sessionBytes, sessionTTL).Result()
+}
+
+func (repo *SessionRedisRepo) GetSession(ctx context.Context, pin string) (models.Session, error) {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.GetSession")
+	defer span.End()
+
+	sessionBytes, err := repo.redisClient.Get(ctx, "session:"+pin).Bytes()
+
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	var session models.Session
+
+	if err := json.Unmarshal(sessionBytes, &session); err != nil {
+		return models.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (repo *SessionRedisRepo) UpdateSession(ctx context.Context, session models.Session) error {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.UpdateSession")
+	defer span.End()
+
+	sessionBytes, err := json.Marshal(session)
+
+	if err != nil {
+		return err
+	}
+
+	return repo.redisClient.Set(ctx, "session:"+session.PIN, sessionBytes, redis.KeepTTL).Err()
+}
+
+func (repo *SessionRedisRepo) AddPlayer(ctx context.Context, pin string, player models.SessionPlayer) (bool, error) {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.AddPlayer")
+	defer span.End()
+
+	added, err := repo.redisClient.HSetNX(ctx, "session:"+pin+":players", player.Nickname, player.Token).Result()
+
+	if err != nil || !added {
+		return false, err
+	}
+
+	pipe := repo.redisClient.TxPipeline()
+	pipe.HSet(ctx, "session:"+pin+":tokens", player.Token, player.Nickname)
+	pipe.ZAddNX(ctx, "session:"+pin+":scores", redis.Z{Member: player.Nickname})
+	pipe.Expire(ctx, "session:"+pin+":players", sessionTTL)
+	pipe.Expire(ctx, "session:"+pin+":tokens", sessionTTL)
+	pipe.Expire(ctx, "session:"+pin+":scores", sessionTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo *SessionRedisRepo) GetPlayer(ctx context.Context, pin, token string) (string, error) {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.GetPlayer")
+	defer span.End()
+
+	return repo.redisClient.HGet(ctx, "session:"+pin+":tokens", token).Result()
+}
+
+func (repo *SessionRedisRepo) GetPlayers(ctx context.Context, pin string) ([]string, error) {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.GetPlayers")
+	defer span.End()
+
+	return repo.redisClient.HKeys(ctx, "session:"+pin+":players").Result()
+}
+
+func (repo *SessionRedisRepo) SaveAnswer(ctx context.Context, pin string, index int, nickname string, answer models.SessionAnswer) (bool, error) {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.SaveAnswer")
+	defer span.End()
+
+	answerBytes, err := json.Marshal(answer)
+
+	if err != nil {
+		return false, err
+	}
+
+	key := "session:" + pin + ":answers:" + strconv.Itoa(index)
+
+	saved, err := repo.redisClient.HSetNX(ctx, key, nickname, answerBytes).Result()
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := repo.redisClient.Expire(ctx, key, sessionTTL).Err(); err != nil {
+		return false, err
+	}
+
+	return saved, nil
+}
+
+func (repo *SessionRedisRepo) GetAnswers(ctx context.Context, pin string, index int) (map[string]models.SessionAnswer, error) {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.GetAnswers")
+	defer span.End()
+
+	values, err := repo.redisClient.HGetAll(ctx, "session:"+pin+":answers:"+strconv.Itoa(index)).Result()
+
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make(map[string]models.SessionAnswer, len(values))
+
+	for nickname, value := range values {
+		var answer models.SessionAnswer
+
+		if err := json.Unmarshal([]byte(value), &answer); err != nil {
+			return nil, err
+		}
+
+		answers[nickname] = answer
+	}
+
+	return answers, nil
+}
+
+func (repo *SessionRedisRepo) CloseQuestion(ctx context.Context, pin string, index int) (bool, error) {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.CloseQuestion")
+	defer span.End()
+
+	return repo.redisClient.SetNX(ctx, "session:"+pin+":closed:"+strconv.Itoa(index), true, sessionTTL).Result()
+}
+
+func (repo *SessionRedisRepo) AddScore(ctx context.Context, pin, nickname string, score float64) error {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.AddScore")
+	defer span.End()
+
+	return repo.redisClient.ZIncrBy(ctx, "session:"+pin+":scores", score, nickname).Err()
+}
+
+func (repo *SessionRedisRepo) GetLeaderboard(ctx context.Context, pin string) ([]models.SessionScore, error) {
+	ctx, span := repo.tracer.Start(ctx, "sessionRedisRepo.GetLeaderboard")
+	defer span.End()
+
+	scores, err := repo.redisClient.ZRevRangeWithScores(ctx, "session:"+pin+":scores", 0, -1).Result()
+
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard := make([]models.SessionScore, 0, len(scores))
+
+	for _, score := range scores {
+		leaderboard = append(leaderboard, models.SessionScore{
+			Nickname: score.Member.(string),
+			Score:    score.Score,
+		})
+	}
+
+	return leaderboard, nil
+}
@@ -0,0 +1,19 @@
+package session
+
+import (
+	"context"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+)
+
+type Service interface {
+	Create(ctx context.Context, userID int, input domain.Session) (models.Session, error)
+	GetByPIN(ctx context.Context, pin string) (models.Session, error)
+	Join(ctx context.Context, input domain.SessionJoin) (models.SessionPlayer, []string, error)
+	NextQuestion(ctx context.Context, userID int, pin string) (models.SessionQuestion, error)
+	Answer(ctx context.Context, input domain.SessionAnswer) error
+	EndQuestion(ctx context.Context, userID int, pin string) (models.SessionQuestionStats, error)
+	CloseQuestion(ctx context.Context, pin string, index int) (models.SessionQuestionStats, error)
+	GetLeaderboard(ctx context.Context, pin string) ([]models.SessionScore, error)
+	Finish(ctx context.Context, userID int, pin string) ([]models.SessionScore, error)
+}
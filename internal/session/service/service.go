@@ -0,0 +1,506 @@
+package service
+
+import (
+	"context"
+	"github.com/blazee5/quizmaster-backend/internal/answer"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	"github.com/blazee5/quizmaster-backend/internal/question"
+	"github.com/blazee5/quizmaster-backend/internal/question/content"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
+	"github.com/blazee5/quizmaster-backend/internal/quiz"
+	"github.com/blazee5/quizmaster-backend/internal/result"
+	"github.com/blazee5/quizmaster-backend/internal/session"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/blazee5/quizmaster-backend/lib/markdown"
+	"github.com/blazee5/quizmaster-backend/lib/random"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDuration = 20
+	pinLength       = 6
+	pinAttempts     = 5
+	tokenLength     = 32
+	maxPoints       = 1000
+)
+
+type Service struct {
+	log           *zap.SugaredLogger
+	redisRepo     session.RedisRepository
+	quizRepo      quiz.Repository
+	questionRepo  question.Repository
+	answerRepo    answer.Repository
+	resultService result.Service
+	tracer        trace.Tracer
+}
+
+func NewService(log *zap.SugaredLogger, redisRepo session.RedisRepository, quizRepo quiz.Repository, questionRepo question.Repository, answerRepo answer.Repository, resultService result.Service, tracer trace.Tracer) *Service {
+	return &Service{log: log, redisRepo: redisRepo, quizRepo: quizRepo, questionRepo: questionRepo, answerRepo: answerRepo, resultService: resultService, tracer: tracer}
+}
+
+func (s *Service) Create(ctx context.Context, userID int, input domain.Session) (models.Session, error) {
+	ctx, span := s.tracer.Start(ctx, "sessionService.Create")
+	defer span.End()
+
+	quiz, err := s.quizRepo.GetByID(ctx, input.QuizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Session{}, err
+	}
+
+	if quiz.UserID != userID {
+		return models.Session{}, http_errors.ErrPermissionDenied
+	}
+
+	questions, err := s.questionRepo.GetQuestionsByQuizID(ctx, quiz.ID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Session{}, err
+	}
+
+	if len(questions) == 0 {
+		return models.Session{}, http_errors.ErrWrongArgument
+	}
+
+	questionIDs := make([]int, 0, len(questions))
+
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	duration := input.Duration
+
+	if duration == 0 {
+		duration = defaultDuration
+	}
+
+	liveSession := models.Session{
+		QuizID:        quiz.ID,
+		HostID:        userID,
+		Status:        "waiting",
+		Duration:      duration,
+		QuestionIDs:   questionIDs,
+		QuestionIndex: -1,
+		CreatedAt:     time.Now(),
+	}
+
+	for i := 0; i < pinAttempts; i++ {
+		liveSession.PIN, err = random.GeneratePIN(pinLength)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return models.Session{}, err
+		}
+
+		created, err := s.redisRepo.CreateSession(ctx, liveSession)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return models.Session{}, err
+		}
+
+		if created {
+			return liveSession, nil
+		}
+	}
+
+	return models.Session{}, http_errors.ErrWrongArgument
+}
+
+func (s *Service) GetByPIN(ctx context.Context, pin string) (models.Session, error) {
+	ctx, span := s.tracer.Start(ctx, "sessionService.GetByPIN")
+	defer span.End()
+
+	return s.redisRepo.GetSession(ctx, pin)
+}
+
+func (s *Service) Join(ctx context.Context, input domain.SessionJoin) (models.SessionPlayer, []string, error) {
+	ctx, span := s.tracer.Start(ctx, "sessionService.Join")
+	defer span.End()
+
+	nickname := strings.TrimSpace(input.Nickname)
+
+	if nickname == "" || len(nickname) > 32 {
+		return models.SessionPlayer{}, nil, http_errors.ErrWrongArgument
+	}
+
+	liveSession, err := s.redisRepo.GetSession(ctx, input.PIN)
+
+	if err != nil {
+		return models.SessionPlayer{}, nil, err
+	}
+
+	if liveSession.Status == "finished" {
+		return models.SessionPlayer{}, nil, http_errors.ErrPermissionDenied
+	}
+
+	token, err := random.GenerateToken(tokenLength)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.SessionPlayer{}, nil, err
+	}
+
+	player := models.SessionPlayer{
+		Token:    token,
+		Nickname: nickname,
+	}
+
+	added, err := s.redisRepo.AddPlayer(ctx, liveSession.PIN, player)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.SessionPlayer{}, nil, err
+	}
+
+	if !added {
+		return models.SessionPlayer{}, nil, http_errors.ErrNicknameTaken
+	}
+
+	players, err := s.redisRepo.GetPlayers(ctx, liveSession.PIN)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.SessionPlayer{}, nil, err
+	}
+
+	return player, players, nil
+}
+
+func (s *Service) NextQuestion(ctx context.Context, userID int, pin string) (models.SessionQuestion, error) {
+	ctx, span := s.tracer.Start(ctx, "sessionService.NextQuestion")
+	defer span.End()
+
+	liveSession, err := s.redisRepo.GetSession(ctx, pin)
+
+	if err != nil {
+		return models.SessionQuestion{}, err
+	}
+
+	if liveSession.HostID != userID {
+		return models.SessionQuestion{}, http_errors.ErrPermissionDenied
+	}
+
+	if liveSession.Status == "question" || liveSession.Status == "finished" || liveSession.QuestionIndex+1 >= len(liveSession.QuestionIDs) {
+		return models.SessionQuestion{}, http_errors.ErrWrongArgument
+	}
+
+	index := liveSession.QuestionIndex + 1
+
+	question, err := s.questionRepo.GetQuestionByID(ctx, liveSession.QuestionIDs[index])
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.SessionQuestion{}, err
+	}
+
+	answers := make([]models.AnswerInfo, 0)
+
+	if types.HasOptions(question.Type) {
+		answers, err = s.answerRepo.GetAnswersInfoByQuestionID(ctx, question.ID)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return models.SessionQuestion{}, err
+		}
+
+		types.HideKey(question.Type, answers, question.ID)
+		content.AnswersInfo(answers)
+	}
+
+	// The explanation is sent with the question stats once the question is closed.
+	question.Explanation = ""
+	question.TitleHTML = markdown.Render(question.Title)
+
+	endsAt := time.Now().Add(time.Duration(liveSession.Duration) * time.Second)
+
+	liveSession.Status = "question"
+	liveSession.QuestionIndex = index
+	liveSession.EndsAt = &endsAt
+
+	if err := s.redisRepo.UpdateSession(ctx, liveSession); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.SessionQuestion{}, err
+	}
+
+	return models.SessionQuestion{
+		Index:    index,
+		Total:    len(liveSession.QuestionIDs),
+		Question: question,
+		Answers:  answers,
+		EndsAt:   endsAt,
+	}, nil
+}
+
+func (s *Service) Answer(ctx context.Context, input domain.SessionAnswer) error {
+	ctx, span := s.tracer.Start(ctx, "sessionService.Answer")
+	defer span.End()
+
+	liveSession, err := s.redisRepo.GetSession(ctx, input.PIN)
+
+	if err != nil {
+		return err
+	}
+
+	if liveSession.Status != "question" || liveSession.EndsAt == nil || liveSession.EndsAt.Before(time.Now()) {
+		return http_errors.ErrAttemptExpired
+	}
+
+	nickname, err := s.redisRepo.GetPlayer(ctx, liveSession.PIN, input.Token)
+
+	if err != nil {
+		return err
+	}
+
+	answer := models.SessionAnswer{
+		AnswerIDs:  input.AnswerIDs,
+		Text:       input.AnswerText,
+		AnsweredAt: time.Now(),
+	}
+
+	for _, pair := range input.Pairs {
+		answer.Pairs = append(answer.Pairs, models.AnswerPair{AnswerID: pair.AnswerID, MatchText: pair.MatchText})
+	}
+
+	if input.AnswerID != 0 {
+		answer.AnswerIDs = []int{input.AnswerID}
+	}
+
+	saved, err := s.redisRepo.SaveAnswer(ctx, liveSession.PIN, liveSession.QuestionIndex, nickname, answer)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	if !saved {
+		return http_errors.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+func (s *Service) EndQuestion(ctx context.Context, userID int, pin string) (models.SessionQuestionStats, error) {
+	ctx, span := s.tracer.Start(ctx, "sessionService.EndQuestion")
+	defer span.End()
+
+	liveSession, err := s.redisRepo.GetSession(ctx, pin)
+
+	if err != nil {
+		return models.SessionQuestionStats{}, err
+	}
+
+	if liveSession.HostID != userID {
+		return models.SessionQuestionStats{}, http_errors.ErrPermissionDenied
+	}
+
+	return s.CloseQuestion(ctx, pin, liveSession.QuestionIndex)
+}
+
+func (s *Service) CloseQuestion(ctx context.Context, pin string, index int) (models.SessionQuestionStats, error) {
+	ctx, span := s.tracer.Start(ctx, "sessionService.CloseQuestion")
+	defer span.End()
+
+	liveSession, err := s.redisRepo.GetSession(ctx, pin)
+
+	if err != nil {
+		return models.SessionQuestionStats{}, err
+	}
+
+	if liveSession.Status != "question" || liveSession.QuestionIndex != index {
+		return models.SessionQuestionStats{}, http_errors.ErrWrongArgument
+	}
+
+	closed, err := s.redisRepo.CloseQuestion(ctx, pin, index)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.SessionQuestionStats{}, err
+	}
+
+	if !closed {
+		return models.SessionQuestionStats{}, http_errors.ErrWrongArgument
+	}
+
+	startedAt := liveSession.EndsAt.Add(-time.Duration(liveSession.Duration) * time.Second)
+
+	liveSession.Status = "results"
+	liveSession.EndsAt = nil
+
+	if err := s.redisRepo.UpdateSession(ctx, liveSession); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.SessionQuestionStats{}, err
+	}
+
+	questions, err := s.questionRepo.GetQuestionsAuthor(ctx, liveSession.QuizID)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.SessionQuestionStats{}, err
+	}
+
+	var question models.QuestionWithAnswers
+
+	for _, q := range questions {
+		if q.ID == liveSession.QuestionIDs[index] {
+			question = q
+		}
+	}
+
+	answers, err := s.redisRepo.GetAnswers(ctx, pin, index)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.SessionQuestionStats{}, err
+	}
+
+	stats := models.SessionQuestionStats{
+		Index:           index,
+		QuestionID:      question.ID,
+		Answered:        len(answers),
+		Answers:         make([]models.SessionAnswerStats, 0, len(question.Answers)),
+		Explanation:     question.Explanation,
+		ExplanationHTML: markdown.Render(question.Explanation),
+	}
+
+	counts := make(map[int]int)
+
+	for nickname, answer := range answers {
+		if question.Type == types.Ordering {
+			answer.AnswerIDs = types.UnmaskIDs(question.Answers, answer.AnswerIDs, question.ID)
+		}
+
+		userAnswers := make([]models.UserAnswer, 0, len(answer.AnswerIDs))
+
+		for _, answerID := range answer.AnswerIDs {
+			userAnswers = append(userAnswers, models.UserAnswer{AnswerID: answerID})
+			counts[answerID]++
+		}
+
+		for _, pair := range answer.Pairs {
+			userAnswers = append(userAnswers, models.UserAnswer{AnswerID: pair.AnswerID, Text: pair.MatchText})
+		}
+
+		if answer.Text != "" {
+			userAnswers = append(userAnswers, models.UserAnswer{Text: answer.Text})
+		}
+
+		score := s.resultService.GradeQuestion(question, userAnswers)
+
+		if score == 0 {
+			continue
+		}
+
+		if score == 1 {
+			stats.Correct++
+		}
+
+		points := sessionPoints(score, question.Points, answer.AnsweredAt.Sub(startedAt), time.Duration(liveSession.Duration)*time.Second)
+
+		if err := s.redisRepo.AddScore(ctx, pin, nickname, points); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return models.SessionQuestionStats{}, err
+		}
+	}
+
+	if types.IsChoice(question.Type) {
+		for _, answer := range question.Answers {
+			stats.Answers = append(stats.Answers, models.SessionAnswerStats{
+				ID:        answer.ID,
+				Text:      answer.Text,
+				IsCorrect: answer.IsCorrect,
+				Count:     counts[answer.ID],
+			})
+		}
+	}
+
+	return stats, nil
+}
+
+func (s *Service) GetLeaderboard(ctx context.Context, pin string) ([]models.SessionScore, error) {
+	ctx, span := s.tracer.Start(ctx, "sessionService.GetLeaderboard")
+	defer span.End()
+
+	return s.redisRepo.GetLeaderboard(ctx, pin)
+}
+
+func (s *Service) Finish(ctx context.Context, userID int, pin string) ([]models.SessionScore, error) {
+	ctx, span := s.tracer.Start(ctx, "sessionService.Finish")
+	defer span.End()
+
+	liveSession, err := s.redisRepo.GetSession(ctx, pin)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if liveSession.HostID != userID {
+		return nil, http_errors.ErrPermissionDenied
+	}
+
+	if liveSession.Status == "question" {
+		if _, err := s.CloseQuestion(ctx, pin, liveSession.QuestionIndex); err != nil {
+			return nil, err
+		}
+	}
+
+	liveSession.Status = "finished"
+	liveSession.EndsAt = nil
+
+	if err := s.redisRepo.UpdateSession(ctx, liveSession); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	return s.redisRepo.GetLeaderboard(ctx, pin)
+}
+
+// sessionPoints rewards fast answers: an answer given instantly earns the full
+// amount and one given at the last moment earns half of it.
+func sessionPoints(score float64, points int, elapsed, duration time.Duration) float64 {
+	speed := 1 - min(max(elapsed.Seconds()/duration.Seconds(), 0), 1)/2
+
+	return math.Round(maxPoints * float64(points) * score * speed)
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"github.com/blazee5/quizmaster-backend/internal/domain"
+	"github.com/blazee5/quizmaster-backend/internal/models"
+	mock_question "github.com/blazee5/quizmaster-backend/internal/question/mock"
+	"github.com/blazee5/quizmaster-backend/internal/question/types"
+	mock_quiz "github.com/blazee5/quizmaster-backend/internal/quiz/mock"
+	mock_result "github.com/blazee5/quizmaster-backend/internal/result/mock"
+	mock_session "github.com/blazee5/quizmaster-backend/internal/session/mock"
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"github.com/blazee5/quizmaster-backend/lib/logger"
+	"github.com/blazee5/quizmaster-backend/lib/tracer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+type mocks struct {
+	redisRepo     *mock_session.MockRedisRepository
+	quizRepo      *mock_quiz.MockRepository
+	questionRepo  *mock_question.MockRepository
+	resultService *mock_result.MockService
+}
+
+func newTestService(t *testing.T) (*Service, mocks) {
+	ctrl := gomock.NewController(t)
+
+	m := mocks{
+		redisRepo:     mock_session.NewMockRedisRepository(ctrl),
+		quizRepo:      mock_quiz.NewMockRepository(ctrl),
+		questionRepo:  mock_question.NewMockRepository(ctrl),
+		resultService: mock_result.NewMockService(ctrl),
+	}
+
+	return NewService(logger.NewLogger(), m.redisRepo, m.quizRepo, m.questionRepo, nil, m.resultService, tracer.InitTracer("main")), m
+}
+
+func TestSessionPoints(t *testing.T) {
+	t.Parallel()
+
+	duration := 20 * time.Second
+
+	require.Equal(t, 1000.0, sessionPoints(1, 1, 0, duration))
+	require.Equal(t, 750.0, sessionPoints(1, 1, 10*time.Second, duration))
+	require.Equal(t, 500.0, sessionPoints(1, 1, 30*time.Second, duration))
+	require.Equal(t, 1000.0, sessionPoints(0.5, 2, 0, duration))
+	require.Equal(t, 0.0, sessionPoints(0, 1, 0, duration))
+}
+
+func TestCreateRetriesPINCollision(t *testing.T) {
+	t.Parallel()
+
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	m.quizRepo.EXPECT().GetByID(gomock.Any(), 1).Return(models.Quiz{ID: 1, UserID: 7}, nil)
+	m.questionRepo.EXPECT().GetQuestionsByQuizID(gomock.Any(), 1).Return([]models.Question{{ID: 5}}, nil)
+
+	var pins []string
+
+	m.redisRepo.EXPECT().CreateSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, session models.Session) (bool, error) {
+		pins = append(pins, session.PIN)
+
+		return len(pins) > 1, nil
+	}).Times(2)
+
+	liveSession, err := s.Create(ctx, 7, domain.Session{QuizID: 1})
+
+	require.NoError(t, err)
+	require.Len(t, pins, 2)
+	require.Equal(t, pins[1], liveSession.PIN)
+	require.Regexp(t, `^[0-9]{6}$`, liveSession.PIN)
+	require.Equal(t, []int{5}, liveSession.QuestionIDs)
+	require.Equal(t, defaultDuration, liveSession.Duration)
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		nickname string
+		status   string
+		added    bool
+		expected error
+	}{
+		{"success", "alice", "waiting", true, nil},
+		{"empty nickname", "  ", "waiting", false, http_errors.ErrWrongArgument},
+		{"finished session", "alice", "finished", false, http_errors.ErrPermissionDenied},
+		{"nickname taken", "alice", "waiting", false, http_errors.ErrNicknameTaken},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, m := newTestService(t)
+			ctx := context.Background()
+
+			if tt.expected != http_errors.ErrWrongArgument {
+				m.redisRepo.EXPECT().GetSession(gomock.Any(), "123456").Return(models.Session{PIN: "123456", Status: tt.status}, nil)
+			}
+
+			if tt.status != "finished" && tt.expected != http_errors.ErrWrongArgument {
+				m.redisRepo.EXPECT().AddPlayer(gomock.Any(), "123456", gomock.Any()).Return(tt.added, nil)
+			}
+
+			if tt.added {
+				m.redisRepo.EXPECT().GetPlayers(gomock.Any(), "123456").Return([]string{"bob", "alice"}, nil)
+			}
+
+			player, players, err := s.Join(ctx, domain.SessionJoin{PIN: "123456", Nickname: tt.nickname})
+
+			if tt.expected != nil {
+				require.ErrorIs(t, err, tt.expected)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "alice", player.Nickname)
+			require.Len(t, player.Token, tokenLength)
+			require.Equal(t, []string{"bob", "alice"}, players)
+		})
+	}
+}
+
+func TestAnswer(t *testing.T) {
+	t.Parallel()
+
+	future := time.Now().Add(10 * time.Second)
+	past := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name     string
+		status   string
+		endsAt   *time.Time
+		saved    bool
+		expected error
+	}{
+		{"success", "question", &future, true, nil},
+		{"duplicate answer", "question", &future, false, http_errors.ErrPermissionDenied},
+		{"late answer", "question", &past, false, http_errors.ErrAttemptExpired},
+		{"question closed", "results", nil, false, http_errors.ErrAttemptExpired},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, m := newTestService(t)
+			ctx := context.Background()
+
+			m.redisRepo.EXPECT().GetSession(gomock.Any(), "123456").Return(models.Session{
+				PIN:           "123456",
+				Status:        tt.status,
+				QuestionIndex: 2,
+				EndsAt:        tt.endsAt,
+			}, nil)
+
+			if tt.expected != http_errors.ErrAttemptExpired {
+				m.redisRepo.EXPECT().GetPlayer(gomock.Any(), "123456", "token").Return("alice", nil)
+				m.redisRepo.EXPECT().SaveAnswer(gomock.Any(), "123456", 2, "alice", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, _ int, _ string, answer models.SessionAnswer) (bool, error) {
+						require.Equal(t, []int{10}, answer.AnswerIDs)
+
+						return tt.saved, nil
+					})
+			}
+
+			err := s.Answer(ctx, domain.SessionAnswer{PIN: "123456", Token: "token", AnswerID: 10})
+
+			if tt.expected != nil {
+				require.ErrorIs(t, err, tt.expected)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCloseQuestion(t *testing.T) {
+	t.Parallel()
+
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	endsAt := time.Now().Add(20 * time.Second)
+	startedAt := endsAt.Add(-20 * time.Second)
+
+	liveSession := models.Session{
+		PIN:           "123456",
+		QuizID:        1,
+		Status:        "question",
+		Duration:      20,
+		QuestionIDs:   []int{4, 5},
+		QuestionIndex: 1,
+		EndsAt:        &endsAt,
+	}
+
+	question := models.QuestionWithAnswers{
+		ID:     5,
+		Type:   types.Choice,
+		Points: 1,
+		Answers: []models.Answer{
+			{ID: 50, Text: "right", IsCorrect: true},
+			{ID: 51, Text: "wrong"},
+		},
+	}
+
+	m.redisRepo.EXPECT().GetSession(gomock.Any(), "123456").Return(liveSession, nil)
+	m.redisRepo.EXPECT().CloseQuestion(gomock.Any(), "123456", 1).Return(true, nil)
+	m.redisRepo.EXPECT().UpdateSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, session models.Session) error {
+		require.Equal(t, "results", session.Status)
+		require.Nil(t, session.EndsAt)
+
+		return nil
+	})
+	m.questionRepo.EXPECT().GetQuestionsAuthor(gomock.Any(), 1).Return([]models.QuestionWithAnswers{{ID: 4}, question}, nil)
+	m.redisRepo.EXPECT().GetAnswers(gomock.Any(), "123456", 1).Return(map[string]models.SessionAnswer{
+		"alice": {AnswerIDs: []int{50}, AnsweredAt: startedAt},
+		"bob":   {AnswerIDs: []int{51}, AnsweredAt: startedAt},
+	}, nil)
+	m.resultService.EXPECT().GradeQuestion(question, gomock.Any()).DoAndReturn(func(_ models.QuestionWithAnswers, answers []models.UserAnswer) float64 {
+		if answers[0].AnswerID == 50 {
+			return 1
+		}
+
+		return 0
+	}).Times(2)
+	m.redisRepo.EXPECT().AddScore(gomock.Any(), "123456", "alice", float64(maxPoints)).Return(nil)
+
+	stats, err := s.CloseQuestion(ctx, "123456", 1)
+
+	require.NoError(t, err)
+	require.Equal(t, 5, stats.QuestionID)
+	require.Equal(t, 2, stats.Answered)
+	require.Equal(t, 1, stats.Correct)
+	require.Equal(t, []models.SessionAnswerStats{
+		{ID: 50, Text: "right", IsCorrect: true, Count: 1},
+		{ID: 51, Text: "wrong", Count: 1},
+	}, stats.Answers)
+}
+
+func TestCloseQuestionAlreadyClosed(t *testing.T) {
+	t.Parallel()
+
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	endsAt := time.Now()
+
+	m.redisRepo.EXPECT().GetSession(gomock.Any(), "123456").Return(models.Session{
+		PIN:           "123456",
+		Status:        "question",
+		QuestionIndex: 0,
+		EndsAt:        &endsAt,
+	}, nil)
+	m.redisRepo.EXPECT().CloseQuestion(gomock.Any(), "123456", 0).Return(false, nil)
+
+	_, err := s.CloseQuestion(ctx, "123456", 0)
+
+	require.ErrorIs(t, err, http_errors.ErrWrongArgument)
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	t.Parallel()
+
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	scores := []models.SessionScore{{Nickname: "alice", Score: 1000}, {Nickname: "bob", Score: 500}}
+
+	m.redisRepo.EXPECT().GetLeaderboard(gomock.Any(), "123456").Return(scores, nil)
+
+	leaderboard, err := s.GetLeaderboard(ctx, "123456")
+
+	require.NoError(t, err)
+	require.Equal(t, scores, leaderboard)
+}
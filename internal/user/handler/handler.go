@@ -66,7 +66,13 @@ func (h *Handler) GetByID(c echo.Context) error {
 		})
 	}
 
-	user, err := h.service.GetByID(ctx, userID)
+	getUser := h.service.GetProfile
+
+	if userID == c.Get("userID").(int) {
+		getUser = h.service.GetByID
+	}
+
+	user, err := getUser(ctx, userID)
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return c.JSON(http.StatusNotFound, echo.Map{
@@ -49,12 +49,13 @@ func (repo *Repository) GetByID(ctx context.Context, userID int) (models.UserInf
 	userResults := make([]models.UserResult, 0)
 	processedQuizzes := make([]int, 0)
 
-	query := `SELECT q.id, q.title, q.description, q.image, q.user_id, q.created_at, r.score,
+	query := `SELECT q.id, q.title, q.description, q.image, q.user_id, q.created_at, r.score, r.max_score,
+       COALESCE(r.score * 100 / NULLIF(r.max_score, 0), 0) AS percentage,
        (SELECT COUNT(*) FROM questions WHERE questions.quiz_id = q.id) AS questions_count, r.created_at
 		FROM results r
 		INNER JOIN quizzes q ON r.quiz_id = q.id
 		WHERE r.user_id = $1 AND r.is_completed = true
-		GROUP BY q.id, r.score, r.is_completed, r.created_at
+		GROUP BY q.id, r.score, r.max_score, r.is_completed, r.created_at
 		ORDER BY r.score DESC`
 
 	if err != nil {
@@ -87,6 +88,8 @@ func (repo *Repository) GetByID(ctx context.Context, userID int) (models.UserInf
 			&quiz.UserID,
 			&quiz.CreatedAt,
 			&userResult.Score,
+			&userResult.MaxScore,
+			&userResult.Percentage,
 			&userResult.QuestionsCount,
 			&userResult.CreatedAt,
 		)
@@ -9,6 +9,7 @@ import (
 
 type Service interface {
 	GetByID(ctx context.Context, userID int) (models.UserInfo, error)
+	GetProfile(ctx context.Context, userID int) (models.UserInfo, error)
 	ChangeAvatar(ctx context.Context, userID int, fileHeader *multipart.FileHeader) error
 	Update(ctx context.Context, userID int, input domain.UpdateUser) error
 	Delete(ctx context.Context, userID int) error
@@ -55,6 +55,32 @@ func (s *Service) GetByID(ctx context.Context, userID int) (models.UserInfo, err
 	return user, nil
 }
 
+// GetProfile returns the user as seen by other users: only published public
+// quizzes are listed.
+func (s *Service) GetProfile(ctx context.Context, userID int) (models.UserInfo, error) {
+	ctx, span := s.tracer.Start(ctx, "userService.GetProfile")
+	defer span.End()
+
+	user, err := s.GetByID(ctx, userID)
+
+	if err != nil {
+		return models.UserInfo{}, err
+	}
+
+	quizzes := make([]models.Quiz, 0, len(user.Quizzes))
+
+	for _, quiz := range user.Quizzes {
+		if quiz.Status == "published" && quiz.Visibility == "public" {
+			quiz.AccessCode = ""
+			quizzes = append(quizzes, quiz)
+		}
+	}
+
+	user.Quizzes = quizzes
+
+	return user, nil
+}
+
 func (s *Service) ChangeAvatar(ctx context.Context, userID int, fileHeader *multipart.FileHeader) error {
 	ctx, span := s.tracer.Start(ctx, "userService.ChangeAvatar")
 	defer span.End()
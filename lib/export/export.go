@@ -0,0 +1,98 @@
+package export
+
+import (
+	"encoding/csv"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"strings"
+)
+
+const sheetName = "Sheet1"
+
+// formulaPrefixes start a formula in spreadsheet apps, cells beginning with them
+// are quoted so user input is never evaluated.
+const formulaPrefixes = "=+-@\t\r"
+
+type Writer interface {
+	Write(row []string) error
+	Close() error
+}
+
+type CSVWriter struct {
+	writer *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *CSVWriter) Write(row []string) error {
+	values := make([]string, 0, len(row))
+
+	for _, value := range row {
+		values = append(values, escapeCell(value))
+	}
+
+	return w.writer.Write(values)
+}
+
+func (w *CSVWriter) Close() error {
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+type XLSXWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func NewXLSXWriter(w io.Writer) (*XLSXWriter, error) {
+	file := excelize.NewFile()
+
+	stream, err := file.NewStreamWriter(sheetName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &XLSXWriter{out: w, file: file, stream: stream}, nil
+}
+
+func (w *XLSXWriter) Write(row []string) error {
+	w.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, 0, len(row))
+
+	for _, value := range row {
+		values = append(values, escapeCell(value))
+	}
+
+	return w.stream.SetRow(cell, values)
+}
+
+func (w *XLSXWriter) Close() error {
+	defer w.file.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+
+	return w.file.Write(w.out)
+}
+
+func escapeCell(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
@@ -0,0 +1,20 @@
+package export
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCSVWriterEscapesFormulas(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	writer := NewCSVWriter(&buf)
+
+	require.NoError(t, writer.Write([]string{"=HYPERLINK(\"http://evil\")", "+1", "-2", "@SUM(A1)", "\tx", "\rx", "plain", "1.5", ""}))
+	require.NoError(t, writer.Close())
+
+	require.Equal(t, "\"'=HYPERLINK(\"\"http://evil\"\")\",'+1,'-2,'@SUM(A1),'\tx,\"'\rx\",plain,1.5,\n", buf.String())
+}
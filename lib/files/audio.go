@@ -0,0 +1,61 @@
+package files
+
+import (
+	"github.com/blazee5/quizmaster-backend/lib/http_errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+func PrepareAudio(fileHeader *multipart.FileHeader) (string, []byte, string, error) {
+	file, err := fileHeader.Open()
+
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	defer file.Close()
+
+	bytes, err := io.ReadAll(file)
+
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	contentType := detectAudioMime(bytes)
+
+	if contentType == "" {
+		return "", nil, "", http_errors.ErrInvalidAudio
+	}
+
+	fileName, err := GenerateFileName(fileHeader.Filename)
+
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	return contentType, bytes, fileName, nil
+}
+
+// detectAudioMime returns the content type of an audio clip or an empty string if it is not one.
+// MP3 files without an ID3 tag are not recognized by http.DetectContentType, so their frame header is checked too.
+func detectAudioMime(data []byte) string {
+	var audioMimeTypes = map[string]struct{}{
+		"audio/aiff":      {},
+		"audio/mpeg":      {},
+		"audio/wave":      {},
+		"application/ogg": {},
+	}
+
+	contentType := http.DetectContentType(data)
+
+	if _, ok := audioMimeTypes[contentType]; ok {
+		return contentType
+	}
+
+	if len(data) > 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0 {
+		return "audio/mpeg"
+	}
+
+	return ""
+}
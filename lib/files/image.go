@@ -29,17 +29,25 @@ func PrepareImage(fileHeader *multipart.FileHeader) (string, []byte, string, err
 		return "", nil, "", http_errors.ErrInvalidImage
 	}
 
-	id, err := uuid.NewUUID()
+	fileName, err := GenerateFileName(fileHeader.Filename)
 
 	if err != nil {
 		return "", nil, "", err
 	}
 
-	fileName := id.String() + filepath.Ext(fileHeader.Filename)
-
 	return contentType, bytes, fileName, nil
 }
 
+func GenerateFileName(fileName string) (string, error) {
+	id, err := uuid.NewUUID()
+
+	if err != nil {
+		return "", err
+	}
+
+	return id.String() + filepath.Ext(fileName), nil
+}
+
 func checkImageMime(imageMime string) bool {
 	var imageMimeTypes = map[string]struct{}{
 		"image/gif":  {},
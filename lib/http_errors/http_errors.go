@@ -3,8 +3,19 @@ package http_errors
 import "errors"
 
 var (
-	ErrPermissionDenied = errors.New("permission denied")
-	ErrWrongArgument    = errors.New("wrong argument")
-	ErrInvalidImage     = errors.New("invalid image")
-	ErrCodeExpired      = errors.New("code is expired")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrWrongArgument      = errors.New("wrong argument")
+	ErrInvalidImage       = errors.New("invalid image")
+	ErrInvalidAudio       = errors.New("invalid audio")
+	ErrCodeExpired        = errors.New("code is expired")
+	ErrAttemptExpired     = errors.New("attempt is expired")
+	ErrAttemptsExceeded   = errors.New("attempts limit exceeded")
+	ErrAttemptCooldown    = errors.New("attempt cooldown is not over")
+	ErrAlreadySubmitted   = errors.New("result is already submitted")
+	ErrNicknameTaken      = errors.New("nickname is taken")
+	ErrInvalidFormat      = errors.New("invalid format")
+	ErrQuizNotPublished   = errors.New("quiz is not published")
+	ErrAccessCodeRequired = errors.New("access code required")
+	ErrQuizNotOpen        = errors.New("quiz is not open yet")
+	ErrQuizClosed         = errors.New("quiz is closed")
 )
@@ -12,6 +12,7 @@ const (
 	EmailConfirmationType = "confirm"
 	ResetEmailType        = "email"
 	ResetPasswordType     = "password"
+	ResultGradedType      = "graded"
 )
 
 func SendMail(emailType, username, email, code string) error {
@@ -19,6 +20,7 @@ func SendMail(emailType, username, email, code string) error {
 		EmailConfirmationType: "../lib/templates/email-confirm.html",
 		ResetEmailType:        "../lib/templates/reset-email.html",
 		ResetPasswordType:     "../lib/templates/reset-password.html",
+		ResultGradedType:      "../lib/templates/result-graded.html",
 	}
 
 	t, err := template.ParseFiles(templates[emailType])
@@ -30,18 +32,23 @@ func SendMail(emailType, username, email, code string) error {
 
 	var link string
 
+	subject := "Account Activation"
+
 	switch emailType {
 	case ResetPasswordType:
 		link = fmt.Sprintf("https://quizer-opal.vercel.app/user/reset/password/%s", code)
 	case ResetEmailType:
 		link = fmt.Sprintf("https://quizer-opal.vercel.app/user/reset/email/%s", code)
+	case ResultGradedType:
+		link = fmt.Sprintf("https://quizer-opal.vercel.app/quiz/%s", code)
+		subject = "Quiz Result"
 	}
 
 	if err := t.Execute(&body, map[string]any{"Link": link, "Username": username}); err != nil {
 		return err
 	}
 
-	message := []byte("Subject: Account Activation\r\n" +
+	message := []byte("Subject: " + subject + "\r\n" +
 		"From: " + os.Getenv("SMTP_FROM") + "\r\n" +
 		"To: " + email + "\r\n" +
 		"MIME-version: 1.0\r\n" +
@@ -0,0 +1,80 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	renderer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	policy   = newPolicy()
+
+	// segmentRe finds the parts of a text that hold math. Code and escaped dollars
+	// are matched as well so that dollars inside them are left alone.
+	segmentRe = regexp.MustCompile("(?s)(```.*?```|~~~.*?~~~|`[^`\n]+`|\\\\\\$)" +
+		`|\$\$(.+?)\$\$` +
+		`|\$([^\s$](?:[^$\n]*[^\s$])?)\$`)
+	placeholderRe = regexp.MustCompile(`QZMATH(\d+)QZ`)
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+
+	return p
+}
+
+// Render converts Markdown into HTML that is safe to show in the browser.
+// Fenced code blocks keep their language as a "language-*" class, and math written
+// as $...$ or $$...$$ is passed through as escaped TeX inside
+// <span class="math inline"> and <span class="math display"> for the client to typeset.
+func Render(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+
+	formulas := make([]string, 0)
+
+	text = segmentRe.ReplaceAllStringFunc(text, func(segment string) string {
+		match := segmentRe.FindStringSubmatch(segment)
+
+		if match[1] != "" {
+			return segment
+		}
+
+		formula := fmt.Sprintf(`<span class="math display">%s</span>`, html.EscapeString(strings.TrimSpace(match[2])))
+
+		if match[2] == "" {
+			formula = fmt.Sprintf(`<span class="math inline">%s</span>`, html.EscapeString(match[3]))
+		}
+
+		formulas = append(formulas, formula)
+
+		return fmt.Sprintf("QZMATH%dQZ", len(formulas)-1)
+	})
+
+	var buf bytes.Buffer
+
+	if err := renderer.Convert([]byte(text), &buf); err != nil {
+		return html.EscapeString(text)
+	}
+
+	// Formulas are put back after sanitizing, they are escaped and cannot carry markup.
+	return placeholderRe.ReplaceAllStringFunc(policy.Sanitize(buf.String()), func(placeholder string) string {
+		i, err := strconv.Atoi(placeholderRe.FindStringSubmatch(placeholder)[1])
+
+		if err != nil || i >= len(formulas) {
+			return placeholder
+		}
+
+		return formulas[i]
+	})
+}
@@ -1,6 +1,9 @@
 package random
 
 import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/big"
 	"math/rand"
 	"time"
 )
@@ -14,3 +17,32 @@ func GenerateVerificationCode(length int) string {
 	}
 	return string(code)
 }
+
+// GeneratePIN returns a numeric PIN of length digits read from crypto/rand.
+func GeneratePIN(length int) (string, error) {
+	pin := make([]byte, length)
+
+	for i := range pin {
+		digit, err := crand.Int(crand.Reader, big.NewInt(10))
+
+		if err != nil {
+			return "", err
+		}
+
+		pin[i] = byte('0' + digit.Int64())
+	}
+
+	return string(pin), nil
+}
+
+// GenerateToken returns a hex token of length characters read from crypto/rand,
+// for secrets that must not be guessable.
+func GenerateToken(length int) (string, error) {
+	buf := make([]byte, (length+1)/2)
+
+	if _, err := crand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf)[:length], nil
+}
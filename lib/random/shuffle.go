@@ -0,0 +1,42 @@
+package random
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"os"
+	"strconv"
+)
+
+var shuffleSecret []byte
+
+// InitShuffleSecret loads the SHUFFLE_SECRET key that Shuffle mixes into its
+// seeds, so the order can't be predicted from public ids alone.
+func InitShuffleSecret() error {
+	secret := os.Getenv("SHUFFLE_SECRET")
+
+	if secret == "" {
+		return errors.New("SHUFFLE_SECRET is not set")
+	}
+
+	shuffleSecret = []byte(secret)
+
+	return nil
+}
+
+// Shuffle reorders items in place with a generator derived from the given seeds,
+// so the same seeds always produce the same order.
+func Shuffle[T any](items []T, seeds ...int) {
+	mac := hmac.New(sha256.New, shuffleSecret)
+
+	for _, seed := range seeds {
+		mac.Write([]byte(strconv.Itoa(seed) + ":"))
+	}
+
+	random := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(mac.Sum(nil)))))
+	random.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+}
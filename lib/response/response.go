@@ -9,7 +9,7 @@ func ValidationError(errs validator.ValidationErrors) []string {
 	var errMessages []string
 	for _, err := range errs {
 		switch err.ActualTag() {
-		case "required":
+		case "required", "required_if":
 			errMessages = append(errMessages, fmt.Sprintf("%s is a required field", err.Field()))
 		case "gt":
 			errMessages = append(errMessages, fmt.Sprintf("field %s must be greater than %v", err.Field(), err.Value()))
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE quizzes ADD COLUMN time_limit INT NOT NULL DEFAULT 0;
+ALTER TABLE results ADD COLUMN expires_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE results DROP COLUMN expires_at;
+ALTER TABLE quizzes DROP COLUMN time_limit;
+-- +goose StatementEnd
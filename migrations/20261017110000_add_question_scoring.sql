@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN scoring VARCHAR(255) NOT NULL DEFAULT 'all_or_nothing';
+ALTER TABLE results ALTER COLUMN score TYPE DOUBLE PRECISION;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE results ALTER COLUMN score TYPE INT;
+ALTER TABLE questions DROP COLUMN scoring;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN points INT NOT NULL DEFAULT 1;
+ALTER TABLE results ADD COLUMN max_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE results r SET max_score = (SELECT COUNT(*) FROM questions q WHERE q.quiz_id = r.quiz_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE results DROP COLUMN max_score;
+ALTER TABLE questions DROP COLUMN points;
+-- +goose StatementEnd
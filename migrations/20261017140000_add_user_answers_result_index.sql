@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX user_answers_result_id_question_id_idx ON user_answers (result_id, question_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX user_answers_result_id_question_id_idx;
+-- +goose StatementEnd
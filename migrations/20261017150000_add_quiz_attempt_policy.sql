@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE quizzes ADD COLUMN max_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE quizzes ADD COLUMN attempt_cooldown INT NOT NULL DEFAULT 0;
+ALTER TABLE quizzes ADD COLUMN score_policy VARCHAR(255) NOT NULL DEFAULT 'best';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE quizzes DROP COLUMN max_attempts;
+ALTER TABLE quizzes DROP COLUMN attempt_cooldown;
+ALTER TABLE quizzes DROP COLUMN score_policy;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE quizzes ADD COLUMN pool_size INT NOT NULL DEFAULT 0;
+ALTER TABLE quizzes ADD COLUMN pool_by_tag BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE questions ADD COLUMN tag VARCHAR(255) NOT NULL DEFAULT '';
+CREATE TABLE result_questions(
+    result_id   INT NOT NULL,
+    question_id INT NOT NULL,
+    PRIMARY KEY (result_id, question_id),
+    FOREIGN KEY (result_id) REFERENCES results (id) ON DELETE CASCADE,
+    FOREIGN KEY (question_id) REFERENCES questions (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE result_questions;
+ALTER TABLE questions DROP COLUMN tag;
+ALTER TABLE quizzes DROP COLUMN pool_by_tag;
+ALTER TABLE quizzes DROP COLUMN pool_size;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE quizzes ADD COLUMN status VARCHAR(255) NOT NULL DEFAULT 'draft';
+CREATE TABLE quiz_versions(
+    id         SERIAL PRIMARY KEY,
+    quiz_id    INT       NOT NULL,
+    version    INT       NOT NULL,
+    questions  JSONB     NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (quiz_id, version),
+    FOREIGN KEY (quiz_id) REFERENCES quizzes (id) ON DELETE CASCADE
+);
+ALTER TABLE quizzes ADD COLUMN version_id INT REFERENCES quiz_versions (id) ON DELETE SET NULL;
+ALTER TABLE results ADD COLUMN version_id INT REFERENCES quiz_versions (id) ON DELETE SET NULL;
+ALTER TABLE user_answers DROP CONSTRAINT user_answers_question_id_fkey;
+ALTER TABLE result_questions DROP CONSTRAINT result_questions_question_id_fkey;
+INSERT INTO quiz_versions (quiz_id, version, questions)
+SELECT z.id, 1, COALESCE((
+    SELECT json_agg(json_build_object(
+        'id', q.id, 'title', q.title, 'image', q.image, 'quiz_id', q.quiz_id, 'type', q.type, 'order_id', q.order_id,
+        'scoring', q.scoring, 'points', q.points, 'tag', q.tag,
+        'answers', COALESCE((
+            SELECT json_agg(json_build_object(
+                'id', a.id, 'text', a.text, 'question_id', a.question_id, 'is_correct', a.is_correct, 'order_id', a.order_id
+            ) ORDER BY a.order_id)
+            FROM answers a WHERE a.question_id = q.id
+        ), '[]')
+    ) ORDER BY q.order_id)
+    FROM questions q WHERE q.quiz_id = z.id
+), '[]')
+FROM quizzes z;
+UPDATE quizzes z SET status = 'published', version_id = v.id FROM quiz_versions v WHERE v.quiz_id = z.id;
+UPDATE results r SET version_id = z.version_id FROM quizzes z WHERE z.id = r.quiz_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM user_answers ua WHERE NOT EXISTS (SELECT 1 FROM questions q WHERE q.id = ua.question_id);
+DELETE FROM result_questions rq WHERE NOT EXISTS (SELECT 1 FROM questions q WHERE q.id = rq.question_id);
+ALTER TABLE result_questions ADD FOREIGN KEY (question_id) REFERENCES questions (id) ON DELETE CASCADE;
+ALTER TABLE user_answers ADD FOREIGN KEY (question_id) REFERENCES questions (id) ON DELETE CASCADE;
+ALTER TABLE results DROP COLUMN version_id;
+ALTER TABLE quizzes DROP COLUMN version_id;
+DROP TABLE quiz_versions;
+ALTER TABLE quizzes DROP COLUMN status;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE quizzes ADD COLUMN visibility VARCHAR(255) NOT NULL DEFAULT 'public';
+ALTER TABLE quizzes ADD COLUMN access_code VARCHAR(64) NOT NULL DEFAULT '';
+CREATE TABLE quiz_invites(
+    quiz_id    INT       NOT NULL,
+    user_id    INT       NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (quiz_id, user_id),
+    FOREIGN KEY (quiz_id) REFERENCES quizzes (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE quiz_invites;
+ALTER TABLE quizzes DROP COLUMN access_code;
+ALTER TABLE quizzes DROP COLUMN visibility;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE quizzes ADD COLUMN opens_at TIMESTAMP;
+ALTER TABLE quizzes ADD COLUMN closes_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE quizzes DROP COLUMN closes_at;
+ALTER TABLE quizzes DROP COLUMN opens_at;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN match_mode VARCHAR(255) NOT NULL DEFAULT 'exact';
+ALTER TABLE questions ADD COLUMN ignore_accents BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE questions ADD COLUMN typo_tolerance INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE questions DROP COLUMN typo_tolerance;
+ALTER TABLE questions DROP COLUMN ignore_accents;
+ALTER TABLE questions DROP COLUMN match_mode;
+-- +goose StatementEnd
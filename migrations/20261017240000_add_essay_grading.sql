@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE results ADD COLUMN pending_review BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE user_answers ALTER COLUMN text TYPE TEXT;
+ALTER TABLE user_answers ADD COLUMN needs_review BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE user_answers ADD COLUMN points DOUBLE PRECISION;
+ALTER TABLE user_answers ADD COLUMN feedback TEXT NOT NULL DEFAULT '';
+CREATE INDEX results_quiz_id_pending_review_idx ON results (quiz_id) WHERE pending_review = true;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX results_quiz_id_pending_review_idx;
+ALTER TABLE user_answers DROP COLUMN feedback;
+ALTER TABLE user_answers DROP COLUMN points;
+ALTER TABLE user_answers DROP COLUMN needs_review;
+ALTER TABLE user_answers ALTER COLUMN text TYPE VARCHAR(255) USING LEFT(text, 255);
+ALTER TABLE results DROP COLUMN pending_review;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ALTER COLUMN title TYPE TEXT;
+ALTER TABLE questions ADD COLUMN explanation TEXT NOT NULL DEFAULT '';
+ALTER TABLE answers ALTER COLUMN text TYPE TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE answers ALTER COLUMN text TYPE VARCHAR(255) USING LEFT(text, 255);
+ALTER TABLE questions DROP COLUMN explanation;
+ALTER TABLE questions ALTER COLUMN title TYPE VARCHAR(255) USING LEFT(title, 255);
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE answers ADD COLUMN image VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE questions ADD COLUMN audio VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE questions DROP COLUMN audio;
+ALTER TABLE answers DROP COLUMN image;
+-- +goose StatementEnd
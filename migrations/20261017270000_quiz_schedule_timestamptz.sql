@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE quizzes ALTER COLUMN opens_at TYPE TIMESTAMPTZ USING opens_at AT TIME ZONE 'UTC';
+ALTER TABLE quizzes ALTER COLUMN closes_at TYPE TIMESTAMPTZ USING closes_at AT TIME ZONE 'UTC';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE quizzes ALTER COLUMN closes_at TYPE TIMESTAMP USING closes_at AT TIME ZONE 'UTC';
+ALTER TABLE quizzes ALTER COLUMN opens_at TYPE TIMESTAMP USING opens_at AT TIME ZONE 'UTC';
+-- +goose StatementEnd